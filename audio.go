@@ -3,10 +3,18 @@ package openai
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	utils "github.com/sashabaranov/go-openai/internal"
 )
@@ -27,6 +35,47 @@ const (
 	AudioResponseFormatVTT         AudioResponseFormat = "vtt"
 )
 
+// Extension returns the file extension of the format, including the dot, e.g. ".srt". Both JSON
+// formats map to ".json". It returns "" for unknown formats.
+func (f AudioResponseFormat) Extension() string {
+	switch f {
+	case AudioResponseFormatJSON, AudioResponseFormatVerboseJSON:
+		return ".json"
+	case AudioResponseFormatText:
+		return ".txt"
+	case AudioResponseFormatSRT:
+		return ".srt"
+	case AudioResponseFormatVTT:
+		return ".vtt"
+	default:
+		return ""
+	}
+}
+
+// MIMEType returns the media type of the format, e.g. "text/vtt". It returns "" for unknown formats.
+func (f AudioResponseFormat) MIMEType() string {
+	switch f {
+	case AudioResponseFormatJSON, AudioResponseFormatVerboseJSON:
+		return "application/json"
+	case AudioResponseFormatText:
+		return "text/plain"
+	case AudioResponseFormatSRT:
+		return "application/x-subrip"
+	case AudioResponseFormatVTT:
+		return "text/vtt"
+	default:
+		return ""
+	}
+}
+
+// accept returns the Accept header value matching the response format, JSON when it is unset.
+func (f AudioResponseFormat) accept() string {
+	if mimeType := f.MIMEType(); mimeType != "" {
+		return mimeType
+	}
+	return "application/json"
+}
+
 type TranscriptionTimestampGranularity string
 
 const (
@@ -34,6 +83,48 @@ const (
 	TranscriptionTimestampGranularitySegment TranscriptionTimestampGranularity = "segment"
 )
 
+// TranscriptionInclude is additional information requested with AudioRequest.Include.
+type TranscriptionInclude string
+
+const (
+	// TranscriptionIncludeLogprobs returns the log probability of every token in
+	// AudioResponse.Logprobs, see AudioResponse.WithWordConfidence.
+	TranscriptionIncludeLogprobs TranscriptionInclude = "logprobs"
+)
+
+var (
+	ErrAudioMissingFilename     = errors.New("audio upload has no filename")
+	ErrAudioUnsupportedFileExt  = errors.New("unsupported audio file extension")
+	ErrInvalidDataURI           = errors.New("invalid audio data URI")
+	ErrPCMFormatRequired        = errors.New("raw PCM audio requires InputSampleRate and InputChannels")
+	ErrModelParamConflict       = errors.New("model parameter conflicts with a request field")
+	ErrTimestampsRequireVerbose = errors.New("timestamp granularities require the verbose_json response format")
+	ErrUploadTooLarge           = errors.New("audio upload exceeds MaxUploadBytes")
+	ErrAmbiguousAudioInput      = errors.New("audio request has both an input and a FilePath naming an existing file")
+)
+
+// rawPCMExtensions are the file extensions of headerless PCM audio.
+var rawPCMExtensions = map[string]bool{
+	".pcm": true,
+	".raw": true,
+}
+
+const defaultPCMEncoding = "pcm_s16le"
+
+// supportedAudioExtensions lists the file types accepted by the transcription endpoints.
+var supportedAudioExtensions = map[string]bool{
+	".flac": true,
+	".m4a":  true,
+	".mp3":  true,
+	".mp4":  true,
+	".mpeg": true,
+	".mpga": true,
+	".oga":  true,
+	".ogg":  true,
+	".wav":  true,
+	".webm": true,
+}
+
 // AudioRequest represents a request structure for audio API.
 type AudioRequest struct {
 	Model string
@@ -41,15 +132,115 @@ type AudioRequest struct {
 	// FilePath is either an existing file in your filesystem or a filename representing the contents of Reader.
 	FilePath string
 
-	// Reader is an optional io.Reader when you do not want to use an existing file.
+	// Reader is an optional io.Reader when you do not want to use an existing file. When Reader or
+	// DataURI is set, FilePath only names the upload and is never opened, even if such a file
+	// exists; set ClientConfig.StrictAudioInput to reject that case.
 	Reader io.Reader
 
+	// Compressed marks gzip-compressed input, which is assumed for a FilePath ending in ".gz". Such
+	// input is decompressed on the fly while uploading, as the audio endpoints do not accept
+	// compressed request bodies: it saves local storage, not upload bandwidth. The uploaded file is
+	// named after FilePath without its ".gz" suffix. Data URIs are never decompressed.
+	Compressed bool
+
+	// DataURI is an optional "data:audio/wav;base64,..." URI holding the audio. It is decoded in
+	// memory and uploaded with its media type; FilePath, when set, names the uploaded file.
+	DataURI string
+
 	Prompt                 string
 	Temperature            float32
 	Language               string // Only for transcription.
 	Format                 AudioResponseFormat
-	TimestampGranularities []TranscriptionTimestampGranularity // Only for transcription.
+	TimestampGranularities []TranscriptionTimestampGranularity // See CreateTranslation for translations.
 	AudioBase64            string                              `json:"audio_base64,omitempty"`
+	Include                []TranscriptionInclude              // Sent as include[], e.g. to get Logprobs.
+
+	// TargetLanguage is the language CreateTranslation translates into, for backends that support
+	// arbitrary targets. When empty the audio is translated into English. Only for translation; the
+	// OpenAI API only translates into English and ignores this field.
+	TargetLanguage string
+
+	// TranslationTemperature, when set, replaces Temperature in CreateTranslation, so that a request
+	// template shared with CreateTranscription can use a different temperature for each. It is
+	// ignored by transcriptions.
+	TranslationTemperature *float32
+
+	// WordsOnly requests word timestamps alone, in place of TimestampGranularities, and only decodes
+	// the Words of the response: Segments and Text are left empty, which saves work on long audio.
+	// The format must be verbose_json or empty. StrictJSON does not apply to such responses.
+	WordsOnly bool
+
+	// SourceName is copied to AudioResponse.SourceName, to attribute results in batch jobs. It
+	// defaults to FilePath and is never sent to the server.
+	SourceName string
+
+	// PreserveWhitespace is copied to AudioResponse.PreserveWhitespace, see there. The Text of text,
+	// srt and vtt responses is always returned byte for byte, whatever this option.
+	PreserveWhitespace bool
+
+	// Stream requests server-sent transcription events. It is set by CreateTranscriptionStream;
+	// CreateTranscription and CreateTranslation reject requests with Stream set.
+	Stream bool
+
+	// ValidateAudio checks the magic bytes of the input before uploading it, so that corrupt or
+	// non-audio files fail fast with a *NotAudioError instead of being rejected after the upload.
+	// Headerless PCM input has no magic bytes and is not checked.
+	ValidateAudio bool
+
+	// StartOffset and EndOffset select a range of the input to transcribe, e.g. a clip from the
+	// middle of a long recording. The range is cut client-side before the upload, which is only
+	// supported for WAV input, and returned timings are shifted by StartOffset so they refer to the
+	// original recording. A zero EndOffset means the end of the input.
+	StartOffset time.Duration
+	EndOffset   time.Duration
+
+	// Heartbeat, when set, is called every HeartbeatInterval (5 seconds by default) with the time
+	// elapsed while CreateTranscription or CreateTranslation awaits the response, so callers can
+	// show that a long transcription is still running. It stops firing as soon as the call returns
+	// or ctx is done, and is called from another goroutine.
+	Heartbeat         func(elapsed time.Duration)
+	HeartbeatInterval time.Duration
+
+	// NoSpeechFallback, when set, makes CreateTranscription retry once with a higher temperature
+	// when the result is empty or most likely not speech, and return the better of both results.
+	NoSpeechFallback *NoSpeechFallback
+
+	// TemperatureSchedule, when set, overrides Temperature: CreateTranscription transcribes with each
+	// temperature in turn, like the reference Whisper implementation, until a result passes the
+	// quality checks (an average compression ratio of at most 2.4 and an average log probability of
+	// at least -1), and otherwise returns the result of the last temperature. Only verbose_json
+	// responses carry the statistics; other formats stop at the first temperature. NoSpeechFallback
+	// is ignored when it is set.
+	TemperatureSchedule []float32
+
+	// InputChannelSelect, when set, transcribes a single channel of the input, numbered from 0 as in
+	// CreateTranscriptionPerChannel. The channel is extracted client-side before the upload, which
+	// is only supported for WAV and pcm_s16le raw PCM input; a channel the input does not have
+	// fails with ErrInvalidChannelSelect.
+	InputChannelSelect *int
+
+	// NormalizeAudio, when set, scales the loudness of the input before the upload, which helps with
+	// quiet recordings. Only 16-bit PCM WAV and pcm_s16le raw PCM input can be normalized; any other
+	// input fails with ErrNormalizeUnsupportedFormat rather than being sent as is. The input is
+	// processed in memory, without temporary files.
+	NormalizeAudio AudioNormalization
+
+	// InputSampleRate, InputChannels and InputEncoding describe headerless PCM input, i.e. a FilePath
+	// ending in .pcm or .raw, so that backends can decode it. Sample rate and channels are required
+	// for such input; the encoding defaults to "pcm_s16le". They are ignored for other inputs.
+	InputSampleRate int
+	InputChannels   int
+	InputEncoding   string
+
+	// contentType is the Content-Type of the uploaded file, set from ClientConfig.AudioFormatResolver.
+	contentType string
+	// promptField is the form field carrying Prompt, set from ClientConfig.PromptFieldName.
+	promptField string
+
+	// ModelParams are extra form fields passed to the model as is, e.g. experimental tuning knobs
+	// such as "beam_size" that have no typed field. They are written in key order. Keys naming a
+	// field the request already sends, such as "temperature", are rejected with ErrModelParamConflict.
+	ModelParams map[string]string
 }
 
 // AudioResponse represents a response structure for audio API.
@@ -67,6 +258,19 @@ type AudioResponse struct {
 
 	Usage *AudioResponseUsage `json:"usage,omitempty"`
 
+	// Logprobs holds the log probability of every token of Text, when requested with
+	// TranscriptionIncludeLogprobs.
+	Logprobs []AudioLogprob `json:"logprobs,omitempty"`
+
+	// PreserveWhitespace keeps the per-segment spacing verbatim when helpers such as MergeBySpeaker
+	// or Redact rebuild text from segments. By default leading and trailing spaces are trimmed.
+	// It is set from AudioRequest.PreserveWhitespace and never sent by the server.
+	PreserveWhitespace bool `json:"preserve_whitespace,omitempty"`
+
+	// SourceName names the transcribed audio: AudioRequest.SourceName, or its FilePath when unset,
+	// including for chunked and per-channel transcriptions. It is never sent by the server.
+	SourceName string `json:"source_name,omitempty"`
+
 	httpHeader
 }
 
@@ -100,6 +304,19 @@ type AudioWord struct {
 	Word  string  `json:"word"`
 	Start float64 `json:"start"`
 	End   float64 `json:"end"`
+
+	Speaker string `json:"speaker,omitempty"` // 说话人 ID，由 CreateTranscriptionPerChannel 填充
+
+	// Confidence is the probability of the word in [0, 1], or -1 when unknown. It is set by
+	// AudioResponse.WithWordConfidence and never sent by the server.
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// AudioLogprob is the log probability of a token of the transcript.
+type AudioLogprob struct {
+	Token   string  `json:"token"`
+	Logprob float64 `json:"logprob"`
+	Bytes   []int   `json:"bytes,omitempty"`
 }
 
 // TranscriptionAudioInfo 音频元信息（SenseASR 扩展）
@@ -121,19 +338,63 @@ func (r *audioTextResponse) ToAudioResponse() AudioResponse {
 	}
 }
 
+// audioWordsResponse is the part of a verbose_json response decoded for AudioRequest.WordsOnly.
+type audioWordsResponse struct {
+	Task      string                  `json:"task,omitempty"`
+	Language  string                  `json:"language,omitempty"`
+	Duration  float64                 `json:"duration"`
+	Words     []AudioWord             `json:"words"`
+	AudioInfo *TranscriptionAudioInfo `json:"audio_info,omitempty"`
+	Warnings  []string                `json:"warnings,omitempty"`
+	Usage     *AudioResponseUsage     `json:"usage,omitempty"`
+
+	httpHeader
+}
+
+func (r *audioWordsResponse) ToAudioResponse() AudioResponse {
+	return AudioResponse{
+		Task:       r.Task,
+		Language:   r.Language,
+		Duration:   r.Duration,
+		Words:      r.Words,
+		AudioInfo:  r.AudioInfo,
+		Warnings:   r.Warnings,
+		Usage:      r.Usage,
+		httpHeader: r.httpHeader,
+	}
+}
+
 // CreateTranscription — API call to create a transcription. Returns transcribed text.
+//
+// As only verbose_json responses carry timestamps, the format defaults to verbose_json instead of
+// json when request.TimestampGranularities is set, and other formats are rejected with
+// ErrTimestampsRequireVerbose. The same applies to CreateTranslation.
 func (c *Client) CreateTranscription(
 	ctx context.Context,
 	request AudioRequest,
 ) (response AudioResponse, err error) {
+	if len(request.TemperatureSchedule) > 0 {
+		return c.transcribeWithSchedule(ctx, request)
+	}
+	if request.NoSpeechFallback != nil {
+		return c.transcribeWithFallback(ctx, request)
+	}
 	return c.callAudioAPI(ctx, request, "transcriptions")
 }
 
-// CreateTranslation — API call to translate audio into English.
+// CreateTranslation — API call to translate audio into English, or into request.TargetLanguage
+// when the backend supports it.
+//
+// Verbose JSON responses are decoded exactly as for CreateTranscription, including Words when word
+// timestamp granularity is requested. Note that whisper-1 ignores timestamp_granularities on the
+// translations endpoint and returns no words; only backends that time translated words do.
 func (c *Client) CreateTranslation(
 	ctx context.Context,
 	request AudioRequest,
 ) (response AudioResponse, err error) {
+	if request.TranslationTemperature != nil {
+		request.Temperature = *request.TranslationTemperature
+	}
 	return c.callAudioAPI(ctx, request, "translations")
 }
 
@@ -143,12 +404,30 @@ func (c *Client) callAudioAPI(
 	request AudioRequest,
 	endpointSuffix string,
 ) (response AudioResponse, err error) {
+	if request.Stream {
+		return AudioResponse{}, ErrTranscriptionStreamNotSupported
+	}
+	if request, err = request.withTimestampFormat(); err != nil {
+		return AudioResponse{}, err
+	}
+	sourceName := request.sourceName()
+	if request, err = c.prepareAudioRequest(request); err != nil {
+		return AudioResponse{}, err
+	}
+	offset := request.StartOffset
+	if request, err = transformAudioInput(request); err != nil {
+		return AudioResponse{}, err
+	}
+
 	var formBody bytes.Buffer
 	builder := c.createFormBuilder(&formBody)
 
 	if err = audioMultipartForm(request, builder); err != nil {
 		return AudioResponse{}, err
 	}
+	if err = c.checkUploadSize(formBody.Len()); err != nil {
+		return AudioResponse{}, err
+	}
 
 	urlSuffix := fmt.Sprintf("/audio/%s", endpointSuffix)
 	req, err := c.newRequest(
@@ -157,24 +436,170 @@ func (c *Client) callAudioAPI(
 		c.fullURL(urlSuffix, withModel(request.Model)),
 		withBody(&formBody),
 		withContentType(builder.FormDataContentType()),
+		withAccept(request.Format.accept()),
+		withAudioEndpoint(urlSuffix),
 	)
 	if err != nil {
 		return AudioResponse{}, err
 	}
 
-	if request.HasJSONResponse() {
+	stopHeartbeat := startHeartbeat(ctx, request.HeartbeatInterval, request.Heartbeat)
+	switch {
+	case request.WordsOnly:
+		var wordsResponse audioWordsResponse
+		err = c.sendRequest(req, &wordsResponse)
+		response = wordsResponse.ToAudioResponse()
+	case request.HasJSONResponse() && c.config.StrictJSON:
+		err = c.sendRequest(req, &strictResponse{&response})
+	case request.HasJSONResponse():
 		err = c.sendRequest(req, &response)
-	} else {
+	default:
 		var textResponse audioTextResponse
 		err = c.sendRequest(req, &textResponse)
 		response = textResponse.ToAudioResponse()
 	}
+	stopHeartbeat()
 	if err != nil {
 		return AudioResponse{}, err
 	}
+	if response.Task == "" {
+		response.Task = audioTaskForEndpoint(endpointSuffix)
+	}
+	response.PreserveWhitespace = request.PreserveWhitespace
+	response.SourceName = sourceName
+	response.checkDurationConsistency()
+	if offset > 0 {
+		response = response.withOffset(offset.Seconds())
+	}
 	return
 }
 
+// sourceName returns the name AudioResponse.SourceName is set to.
+func (r AudioRequest) sourceName() string {
+	if r.SourceName != "" {
+		return r.SourceName
+	}
+	return r.FilePath
+}
+
+// checkUploadSize returns ErrUploadTooLarge when a request body of size bytes exceeds
+// ClientConfig.MaxUploadBytes.
+func (c *Client) checkUploadSize(size int) error {
+	if limit := c.config.MaxUploadBytes; limit > 0 && int64(size) > limit {
+		return fmt.Errorf("%w: %d bytes, maximum is %d; split WAV input with CreateTranscriptionChunked",
+			ErrUploadTooLarge, size, limit)
+	}
+	return nil
+}
+
+// audioDurationTolerance is the accepted difference between AudioInfo.Duration and Usage.Seconds.
+// Usage.Seconds is billed in whole seconds, so up to one second of rounding is expected.
+const audioDurationTolerance = time.Second
+
+// checkDurationConsistency appends a warning when AudioInfo and Usage disagree on the audio duration.
+func (r *AudioResponse) checkDurationConsistency() {
+	if r.AudioInfo == nil || r.Usage == nil || r.AudioInfo.Duration <= 0 || r.Usage.Seconds <= 0 {
+		return
+	}
+	infoDuration := time.Duration(r.AudioInfo.Duration) * time.Millisecond
+	usageDuration := time.Duration(r.Usage.Seconds) * time.Second
+	diff := infoDuration - usageDuration
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > audioDurationTolerance {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(
+			"audio_info.duration (%dms) does not match usage.seconds (%ds)", r.AudioInfo.Duration, r.Usage.Seconds))
+	}
+}
+
+const (
+	AudioTaskTranscribe = "transcribe"
+	AudioTaskTranslate  = "translate"
+)
+
+// audioTaskForEndpoint returns the task performed by the given audio endpoint.
+func audioTaskForEndpoint(endpointSuffix string) string {
+	if endpointSuffix == "translations" {
+		return AudioTaskTranslate
+	}
+	return AudioTaskTranscribe
+}
+
+// ProcessingTimeHeader is the response header some backends use to report how long they spent
+// processing the audio.
+const ProcessingTimeHeader = "X-Processing-Time"
+
+// ProcessingTime returns the processing time reported by the backend in the X-Processing-Time
+// header, which excludes network and queueing time. The header holds either a Go duration such as
+// "1.5s" or a number of milliseconds. It returns zero when the header is absent or invalid.
+func (r AudioResponse) ProcessingTime() time.Duration {
+	value := strings.TrimSpace(r.Header().Get(ProcessingTimeHeader))
+	if value == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if ms, floatErr := strconv.ParseFloat(value, 64); floatErr == nil {
+		d, err = time.Duration(ms*float64(time.Millisecond)), nil
+	}
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// SourceLanguage returns the language detected in the input audio. For translations the
+// response Text is English, and Language still names the language that was spoken.
+func (r AudioResponse) SourceLanguage() string {
+	return r.Language
+}
+
+// IsTranslation reports whether the response was produced by the translations endpoint.
+func (r AudioResponse) IsTranslation() bool {
+	return r.Task == AudioTaskTranslate
+}
+
+// NewAudioRequestFromMultipart builds an AudioRequest that streams the audio of an uploaded
+// multipart part, without buffering it. The request uses the Whisper1 model; the part's filename
+// must have an extension supported by the audio endpoints.
+func NewAudioRequestFromMultipart(part *multipart.Part) (AudioRequest, error) {
+	filename := part.FileName()
+	if filename == "" {
+		return AudioRequest{}, ErrAudioMissingFilename
+	}
+	if ext := strings.ToLower(filepath.Ext(filename)); !supportedAudioExtensions[ext] {
+		return AudioRequest{}, fmt.Errorf("%w: %q", ErrAudioUnsupportedFileExt, filename)
+	}
+	return AudioRequest{
+		Model:    Whisper1,
+		FilePath: filename,
+		Reader:   part,
+	}, nil
+}
+
+// isRawPCM reports whether the input is headerless PCM audio, according to its filename.
+func (r AudioRequest) isRawPCM() bool {
+	return rawPCMExtensions[strings.ToLower(filepath.Ext(r.uploadFilename()))]
+}
+
+// withTimestampFormat returns the request with Format set to verbose_json when timestamps are
+// requested without a format, and with word timestamps only for WordsOnly.
+func (r AudioRequest) withTimestampFormat() (AudioRequest, error) {
+	if r.WordsOnly {
+		r.TimestampGranularities = []TranscriptionTimestampGranularity{TranscriptionTimestampGranularityWord}
+	}
+	if len(r.TimestampGranularities) == 0 {
+		return r, nil
+	}
+	switch {
+	case r.Format == "":
+		r.Format = AudioResponseFormatVerboseJSON
+	case r.Format != AudioResponseFormatVerboseJSON:
+		return r, fmt.Errorf("%w, got %s", ErrTimestampsRequireVerbose, r.Format)
+	}
+	return r, nil
+}
+
 // HasJSONResponse returns true if the response format is JSON.
 func (r AudioRequest) HasJSONResponse() bool {
 	return r.Format == "" || r.Format == AudioResponseFormatJSON || r.Format == AudioResponseFormatVerboseJSON
@@ -183,11 +608,71 @@ func (r AudioRequest) HasJSONResponse() bool {
 // audioMultipartForm creates a form with audio file contents and the name of the model to use for
 // audio processing.
 func audioMultipartForm(request AudioRequest, b utils.FormBuilder) error {
-	err := createFileField(request, b)
+	err := request.validateForm()
 	if err != nil {
 		return err
 	}
 
+	err = createFileField(request, b)
+	if err != nil {
+		return err
+	}
+
+	if err = writeAudioFields(request, b); err != nil {
+		return err
+	}
+
+	// Close the multipart writer
+	return b.Close()
+}
+
+// audioFormFields are the form fields written by audioMultipartForm, which ModelParams cannot override.
+var audioFormFields = map[string]bool{
+	"file":                      true,
+	"model":                     true,
+	"prompt":                    true,
+	"response_format":           true,
+	"temperature":               true,
+	"language":                  true,
+	"target_language":           true,
+	"stream":                    true,
+	"timestamp_granularities[]": true,
+	"include[]":                 true,
+	"sample_rate":               true,
+	"channels":                  true,
+	"encoding":                  true,
+}
+
+// validateForm checks the fields that are required depending on the input, and ModelParams.
+func (r AudioRequest) validateForm() error {
+	if r.isRawPCM() && (r.InputSampleRate <= 0 || r.InputChannels <= 0) {
+		return ErrPCMFormatRequired
+	}
+	for key := range r.ModelParams {
+		if audioFormFields[key] || key == r.promptFieldName() {
+			return fmt.Errorf("%w: %q", ErrModelParamConflict, key)
+		}
+	}
+	return nil
+}
+
+// promptFieldName returns the form field carrying Prompt, "prompt" by default.
+func (r AudioRequest) promptFieldName() string {
+	if r.promptField != "" {
+		return r.promptField
+	}
+	return "prompt"
+}
+
+// writeAudioFields writes every form field but the file.
+func writeAudioFields(request AudioRequest, b utils.FormBuilder) error {
+	var err error
+	if request.isRawPCM() {
+		if err = writePCMFormatFields(request, b); err != nil {
+			return err
+		}
+	}
+
 	err = b.WriteField("model", request.Model)
 	if err != nil {
 		return fmt.Errorf("writing model name: %w", err)
@@ -195,7 +680,7 @@ func audioMultipartForm(request AudioRequest, b utils.FormBuilder) error {
 
 	// Create a form field for the prompt (if provided)
 	if request.Prompt != "" {
-		err = b.WriteField("prompt", request.Prompt)
+		err = b.WriteField(request.promptFieldName(), request.Prompt)
 		if err != nil {
 			return fmt.Errorf("writing prompt: %w", err)
 		}
@@ -225,6 +710,20 @@ func audioMultipartForm(request AudioRequest, b utils.FormBuilder) error {
 		}
 	}
 
+	if request.TargetLanguage != "" {
+		err = b.WriteField("target_language", request.TargetLanguage)
+		if err != nil {
+			return fmt.Errorf("writing target language: %w", err)
+		}
+	}
+
+	if request.Stream {
+		err = b.WriteField("stream", "true")
+		if err != nil {
+			return fmt.Errorf("writing stream: %w", err)
+		}
+	}
+
 	if len(request.TimestampGranularities) > 0 {
 		for _, tg := range request.TimestampGranularities {
 			err = b.WriteField("timestamp_granularities[]", string(tg))
@@ -234,14 +733,80 @@ func audioMultipartForm(request AudioRequest, b utils.FormBuilder) error {
 		}
 	}
 
-	// Close the multipart writer
-	return b.Close()
+	for _, include := range request.Include {
+		if err = b.WriteField("include[]", string(include)); err != nil {
+			return fmt.Errorf("writing include[]: %w", err)
+		}
+	}
+
+	keys := make([]string, 0, len(request.ModelParams))
+	for key := range request.ModelParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err = b.WriteField(key, request.ModelParams[key]); err != nil {
+			return fmt.Errorf("writing model parameter %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// writePCMFormatFields writes the sample format of raw PCM input.
+func writePCMFormatFields(request AudioRequest, b utils.FormBuilder) error {
+	encoding := request.InputEncoding
+	if encoding == "" {
+		encoding = defaultPCMEncoding
+	}
+	fields := []struct{ name, value string }{
+		{"sample_rate", strconv.Itoa(request.InputSampleRate)},
+		{"channels", strconv.Itoa(request.InputChannels)},
+		{"encoding", encoding},
+	}
+	for _, field := range fields {
+		if err := b.WriteField(field.name, field.value); err != nil {
+			return fmt.Errorf("writing %s: %w", field.name, err)
+		}
+	}
+	return nil
 }
 
 // createFileField creates the "file" form field from either an existing file or by using the reader.
 func createFileField(request AudioRequest, b utils.FormBuilder) error {
+	if request.DataURI != "" {
+		audio, err := decodeAudioDataURI(request.DataURI)
+		if err != nil {
+			return err
+		}
+		if request.ValidateAudio && !request.isRawPCM() {
+			if _, err = ValidateAudioReader(audio); err != nil {
+				return err
+			}
+		}
+		filename := request.FilePath
+		if filename == "" {
+			filename = "audio" + audio.extension()
+		}
+		err = b.CreateFormFileReader("file", audio, filename)
+		if err != nil {
+			return fmt.Errorf("creating form using data URI: %w", err)
+		}
+		return nil
+	}
+
+	if request.isCompressed() {
+		return createCompressedFileField(request, b)
+	}
+
 	if request.Reader != nil {
-		err := b.CreateFormFileReader("file", request.Reader, request.FilePath)
+		reader := request.Reader
+		if request.ValidateAudio && !request.isRawPCM() {
+			var err error
+			if reader, err = ValidateAudioReader(reader); err != nil {
+				return err
+			}
+		}
+		err := b.CreateFormFileReader("file", request.typed(reader), request.FilePath)
 		if err != nil {
 			return fmt.Errorf("creating form using reader: %w", err)
 		}
@@ -254,10 +819,94 @@ func createFileField(request AudioRequest, b utils.FormBuilder) error {
 	}
 	defer f.Close()
 
-	err = b.CreateFormFile("file", f)
+	if request.ValidateAudio && !request.isRawPCM() {
+		if err = validateAudioFile(f); err != nil {
+			return err
+		}
+	}
+
+	if request.contentType != "" {
+		err = b.CreateFormFileReader("file", request.typed(f), f.Name())
+	} else {
+		err = b.CreateFormFile("file", f)
+	}
 	if err != nil {
 		return fmt.Errorf("creating form file: %w", err)
 	}
 
 	return nil
 }
+
+// typedAudio is an upload with a resolved media type, which it exposes to the form builder.
+type typedAudio struct {
+	io.Reader
+
+	contentType string
+}
+
+func (a *typedAudio) ContentType() string {
+	return a.contentType
+}
+
+// typed returns r with the resolved Content-Type of the request, if any.
+func (r AudioRequest) typed(reader io.Reader) io.Reader {
+	if r.contentType == "" {
+		return reader
+	}
+	return &typedAudio{Reader: reader, contentType: r.contentType}
+}
+
+// audioDataURI is the decoded content of a data URI. It exposes its media type to the form builder.
+type audioDataURI struct {
+	*bytes.Reader
+
+	mediaType string
+}
+
+func (a *audioDataURI) ContentType() string {
+	return a.mediaType
+}
+
+// extension returns the file extension matching the media type, or "" when unknown.
+func (a *audioDataURI) extension() string {
+	switch a.mediaType {
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return ".wav"
+	case "audio/mpeg", "audio/mp3":
+		return ".mp3"
+	case "audio/ogg":
+		return ".ogg"
+	case "audio/webm":
+		return ".webm"
+	case "audio/flac", "audio/x-flac":
+		return ".flac"
+	case "audio/mp4", "audio/m4a", "audio/x-m4a":
+		return ".m4a"
+	default:
+		return ""
+	}
+}
+
+// decodeAudioDataURI decodes a base64 data URI of the form data:<media type>[;params];base64,<data>.
+func decodeAudioDataURI(uri string) (*audioDataURI, error) {
+	if !strings.HasPrefix(uri, "data:") {
+		return nil, fmt.Errorf("%w: missing data: scheme", ErrInvalidDataURI)
+	}
+	metadata, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing comma", ErrInvalidDataURI)
+	}
+	params := strings.Split(metadata, ";")
+	if params[len(params)-1] != "base64" {
+		return nil, fmt.Errorf("%w: only base64 encoded data is supported", ErrInvalidDataURI)
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	if mediaType == "" {
+		return nil, fmt.Errorf("%w: missing media type", ErrInvalidDataURI)
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataURI, err) //nolint:errorlint // keep the sentinel matchable
+	}
+	return &audioDataURI{Reader: bytes.NewReader(data), mediaType: mediaType}, nil
+}
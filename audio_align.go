@@ -0,0 +1,151 @@
+package openai
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// AlignedWord is a word of a reference transcript with the timing of the transcribed word it
+// was aligned to, in seconds.
+type AlignedWord struct {
+	Word  string // the reference word, as written
+	Start float64
+	End   float64
+
+	// Matched reports whether the transcribed word is the same word, ignoring case and punctuation.
+	// Unmatched words either took the timing of a different transcribed word at the same position,
+	// or, when the transcription has no counterpart, a zero-length timing where they belong.
+	Matched bool
+}
+
+// AlignWords aligns the whitespace-separated words of reference, e.g. the known script of a
+// recording, to r.Words and returns one AlignedWord per reference word. Matching is best-effort:
+// it minimizes the number of edits between both word sequences, ignoring case and punctuation,
+// allowing both sequences to drift apart by a few hundred words. The response is not modified.
+func (r AudioResponse) AlignWords(reference string) []AlignedWord {
+	refWords := strings.Fields(reference)
+	if len(refWords) == 0 {
+		return nil
+	}
+	ref := make([]string, len(refWords))
+	for i, word := range refWords {
+		ref[i] = alignmentKey(word)
+	}
+	hyp := make([]string, len(r.Words))
+	for j, word := range r.Words {
+		hyp[j] = alignmentKey(word.Word)
+	}
+
+	cost := newEditCosts(ref, hyp)
+
+	aligned := make([]AlignedWord, 0, len(ref))
+	var last float64 // end of the previous aligned word
+	i, j := 0, 0
+	take := func(matched bool) {
+		word := r.Words[j]
+		aligned = append(aligned, AlignedWord{Word: refWords[i], Start: word.Start, End: word.End, Matched: matched})
+		last = word.End
+		i++
+		j++
+	}
+	for i < len(ref) {
+		// Exact matches first; on ties extra transcribed words are skipped before substituting, so a
+		// replaced word takes the timing of the transcribed word closest to the next match.
+		exact := j < len(hyp) && ref[i] == hyp[j]
+		switch {
+		case exact && cost.at(i, j) == cost.at(i+1, j+1):
+			take(true)
+		case j < len(hyp) && cost.at(i, j) == cost.at(i, j+1)+1:
+			// Transcribed word missing from the reference.
+			last = r.Words[j].End
+			j++
+		case j < len(hyp) && !exact && cost.at(i, j) == cost.at(i+1, j+1)+1:
+			take(false)
+		default:
+			// Reference word missing from the transcription.
+			aligned = append(aligned, AlignedWord{Word: refWords[i], Start: last, End: last})
+			i++
+		}
+	}
+	return aligned
+}
+
+// alignmentKey normalizes a word for comparison.
+func alignmentKey(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}))
+}
+
+// alignmentBand is how far, in words, an alignment may stray from the diagonal between both
+// sequences. It bounds memory to O(len(ref) * alignmentBand) instead of O(len(ref) * len(hyp)),
+// so that hour-long transcripts can be aligned.
+const alignmentBand = 256
+
+const unreachableCost = math.MaxInt32 / 2
+
+// editCosts holds the edit distance between ref[i:] and hyp[j:] for the cells (i, j) lying in a
+// band around the diagonal from (0, 0) to (len(ref), len(hyp)). Cells outside are unreachable.
+type editCosts struct {
+	n, m  int
+	width int
+	rows  [][]int32
+}
+
+func newEditCosts(ref, hyp []string) *editCosts {
+	c := &editCosts{n: len(ref), m: len(hyp), width: alignmentBand}
+	if c.n > 0 {
+		// Keep consecutive rows overlapping when hyp is much longer than ref.
+		c.width += c.m/c.n + 1
+	}
+	c.rows = make([][]int32, c.n+1)
+	for i := c.n; i >= 0; i-- {
+		center := c.center(i)
+		c.rows[i] = make([]int32, 2*c.width+1)
+		for j := minInt(c.m, center+c.width); j >= 0 && j >= center-c.width; j-- {
+			var cost int
+			switch {
+			case i == c.n:
+				cost = c.m - j
+			case j == c.m:
+				cost = c.n - i
+			default:
+				substitution := c.at(i+1, j+1)
+				if ref[i] != hyp[j] {
+					substitution++
+				}
+				cost = minInt(substitution, minInt(c.at(i+1, j), c.at(i, j+1))+1)
+			}
+			c.rows[i][j-center+c.width] = int32(minInt(cost, unreachableCost))
+		}
+	}
+	return c
+}
+
+// center returns the column of the diagonal on row i.
+func (c *editCosts) center(i int) int {
+	if c.n == 0 {
+		return 0
+	}
+	return i * c.m / c.n
+}
+
+// at returns the cost of cell (i, j), unreachableCost outside the band.
+func (c *editCosts) at(i, j int) int {
+	if i < 0 || i > c.n || j < 0 || j > c.m {
+		return unreachableCost
+	}
+	k := j - c.center(i) + c.width
+	if k < 0 || k >= len(c.rows[i]) {
+		return unreachableCost
+	}
+	return int(c.rows[i][k])
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
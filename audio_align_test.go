@@ -0,0 +1,80 @@
+package openai_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func alignmentResponse() openai.AudioResponse {
+	return openai.AudioResponse{
+		Words: []openai.AudioWord{
+			{Word: "the", Start: 0, End: 0.2},
+			{Word: "quick", Start: 0.2, End: 0.5},
+			{Word: "brown", Start: 0.5, End: 0.8},
+			{Word: "fox", Start: 0.8, End: 1.1},
+			{Word: "jumps", Start: 1.1, End: 1.5},
+		},
+	}
+}
+
+func TestAlignWordsExact(t *testing.T) {
+	aligned := alignmentResponse().AlignWords("The quick brown fox jumps.")
+	want := []openai.AlignedWord{
+		{Word: "The", Start: 0, End: 0.2, Matched: true},
+		{Word: "quick", Start: 0.2, End: 0.5, Matched: true},
+		{Word: "brown", Start: 0.5, End: 0.8, Matched: true},
+		{Word: "fox", Start: 0.8, End: 1.1, Matched: true},
+		{Word: "jumps.", Start: 1.1, End: 1.5, Matched: true},
+	}
+	if !reflect.DeepEqual(aligned, want) {
+		t.Fatalf("unexpected alignment:\n got %+v\nwant %+v", aligned, want)
+	}
+}
+
+func TestAlignWordsDifferentReference(t *testing.T) {
+	// "quick" was transcribed but is not in the script, "red" replaces "brown"
+	// and "high" was not transcribed at all.
+	aligned := alignmentResponse().AlignWords("The red fox jumps high")
+	want := []openai.AlignedWord{
+		{Word: "The", Start: 0, End: 0.2, Matched: true},
+		{Word: "red", Start: 0.5, End: 0.8, Matched: false},
+		{Word: "fox", Start: 0.8, End: 1.1, Matched: true},
+		{Word: "jumps", Start: 1.1, End: 1.5, Matched: true},
+		{Word: "high", Start: 1.5, End: 1.5, Matched: false},
+	}
+	if !reflect.DeepEqual(aligned, want) {
+		t.Fatalf("unexpected alignment:\n got %+v\nwant %+v", aligned, want)
+	}
+
+	if aligned = (openai.AudioResponse{}).AlignWords("no timings"); len(aligned) != 2 || aligned[0].Matched {
+		t.Errorf("expected unmatched words without transcription words, got %+v", aligned)
+	}
+}
+
+func TestAlignWordsLongTranscript(t *testing.T) {
+	const count = 10000
+	res := openai.AudioResponse{Words: make([]openai.AudioWord, count)}
+	reference := make([]string, 0, count)
+	for i := range res.Words {
+		word := fmt.Sprintf("w%d", i)
+		res.Words[i] = openai.AudioWord{Word: word, Start: float64(i), End: float64(i) + 0.5}
+		// The script misses the first 50 words of the recording.
+		if i >= 50 {
+			reference = append(reference, word)
+		}
+	}
+
+	aligned := res.AlignWords(strings.Join(reference, " "))
+	if len(aligned) != len(reference) {
+		t.Fatalf("expected %d aligned words, got %d", len(reference), len(aligned))
+	}
+	for i, word := range aligned {
+		if !word.Matched || word.Start != float64(i+50) {
+			t.Fatalf("word %d misaligned: %+v", i, word)
+		}
+	}
+}
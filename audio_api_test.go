@@ -2,15 +2,20 @@ package openai_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"io"
 	"mime"
 	"mime/multipart"
 	"net/http"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sashabaranov/go-openai"
 	"github.com/sashabaranov/go-openai/internal/test"
@@ -98,7 +103,7 @@ func TestAudioWithOptionalArgs(t *testing.T) {
 				Prompt:      "用简体中文",
 				Temperature: 0.5,
 				Language:    "zh",
-				Format:      openai.AudioResponseFormatSRT,
+				Format:      openai.AudioResponseFormatVerboseJSON,
 				TimestampGranularities: []openai.TranscriptionTimestampGranularity{
 					openai.TranscriptionTimestampGranularitySegment,
 					openai.TranscriptionTimestampGranularityWord,
@@ -158,3 +163,794 @@ func handleAudioEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func TestAudioTask(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var body string
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+	server.RegisterHandler("/v1/audio/transcriptions", handler)
+	server.RegisterHandler("/v1/audio/translations", handler)
+
+	testcases := []struct {
+		name          string
+		createFn      func(context.Context, openai.AudioRequest) (openai.AudioResponse, error)
+		body          string
+		format        openai.AudioResponseFormat
+		task          string
+		isTranslation bool
+	}{
+		{"transcribe without task", client.CreateTranscription, `{"text":"hi"}`, "", "transcribe", false},
+		{"translate without task", client.CreateTranslation, `{"text":"hi"}`, "", "translate", true},
+		{"translate text format", client.CreateTranslation, "hi", openai.AudioResponseFormatText, "translate", true},
+		{"server task is kept", client.CreateTranscription, `{"task":"translate","text":"hi"}`, "", "translate", true},
+		{
+			"server transcribe task", client.CreateTranscription,
+			`{"task":"transcribe","text":"hi"}`, openai.AudioResponseFormatVerboseJSON, "transcribe", false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body = tc.body
+			res, err := tc.createFn(context.Background(), openai.AudioRequest{
+				Model:    openai.Whisper1,
+				FilePath: "audio.mp3",
+				Reader:   strings.NewReader("audio"),
+				Format:   tc.format,
+			})
+			checks.NoError(t, err, "audio API error")
+			if res.Task != tc.task {
+				t.Errorf("expected task %q, got %q", tc.task, res.Task)
+			}
+			if res.IsTranslation() != tc.isTranslation {
+				t.Errorf("expected IsTranslation %v", tc.isTranslation)
+			}
+		})
+	}
+}
+
+func TestAudioDurationConsistency(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var body string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+
+	testcases := []struct {
+		name     string
+		body     string
+		warnings int
+	}{
+		{"consistent", `{"text":"hi","audio_info":{"duration":4200},"usage":{"type":"duration","seconds":5}}`, 0},
+		{"mismatched", `{"text":"hi","audio_info":{"duration":4200},"usage":{"type":"duration","seconds":60}}`, 1},
+		{
+			"mismatched with server warnings",
+			`{"text":"hi","audio_info":{"duration":90000},"usage":{"seconds":5},"warnings":["low volume"]}`, 2,
+		},
+		{"usage missing", `{"text":"hi","audio_info":{"duration":4200}}`, 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body = tc.body
+			res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+				Model:    openai.Whisper1,
+				FilePath: "audio.mp3",
+				Reader:   strings.NewReader("audio"),
+			})
+			checks.NoError(t, err, "CreateTranscription error")
+			if len(res.Warnings) != tc.warnings {
+				t.Fatalf("expected %d warnings, got %v", tc.warnings, res.Warnings)
+			}
+			if tc.warnings > 0 && !strings.Contains(res.Warnings[tc.warnings-1], "usage.seconds") {
+				t.Errorf("unexpected warning %q", res.Warnings[tc.warnings-1])
+			}
+		})
+	}
+}
+
+func TestAudioGzipTextResponse(t *testing.T) {
+	const srt = "1\n00:00:00,000 --> 00:00:01,500\nHello world\n\n"
+
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(srt))
+		_ = gz.Close()
+	})
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	// The transport does not ask for gzip, so it won't transparently decode the body.
+	config.HTTPClient = &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	client := openai.NewClientWithConfig(config)
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Format:   openai.AudioResponseFormatSRT,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != srt {
+		t.Errorf("expected decompressed SRT %q, got %q", srt, res.Text)
+	}
+
+	// A truncated gzip stream is reported rather than returning partial text.
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		_, _ = gz.Write([]byte(srt))
+		_ = gz.Close()
+		_, _ = w.Write(compressed.Bytes()[:compressed.Len()-4])
+	})
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Format:   openai.AudioResponseFormatSRT,
+	})
+	checks.HasError(t, err, "expected the truncated gzip body to fail")
+}
+
+func TestAudioPreserveWhitespace(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	const text = "  leading spaces\n\ttabs and trailing newline\n"
+	const verbose = `{"text":" Hello  world.","segments":[` +
+		`{"id":0,"start":0,"end":1,"text":" Hello "},{"id":1,"start":1,"end":2,"text":" world."}]}`
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("response_format") == string(openai.AudioResponseFormatText) {
+			_, _ = w.Write([]byte(text))
+			return
+		}
+		_, _ = w.Write([]byte(verbose))
+	})
+
+	for _, preserve := range []bool{false, true} {
+		res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:              openai.Whisper1,
+			FilePath:           "audio.mp3",
+			Reader:             strings.NewReader("audio"),
+			Format:             openai.AudioResponseFormatText,
+			PreserveWhitespace: preserve,
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+		if res.Text != text {
+			t.Errorf("expected exact text bytes %q, got %q", text, res.Text)
+		}
+	}
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:              openai.Whisper1,
+		FilePath:           "audio.mp3",
+		Reader:             strings.NewReader("audio"),
+		Format:             openai.AudioResponseFormatVerboseJSON,
+		PreserveWhitespace: true,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if merged := res.MergeBySpeaker(); len(merged) != 1 || merged[0].Text != " Hello  world." {
+		t.Errorf("expected verbatim merged text, got %+v", merged)
+	}
+	if redacted := res.Redact([]string{"nothing"}, "*"); redacted.Text != " Hello  world." {
+		t.Errorf("expected verbatim rebuilt text, got %q", redacted.Text)
+	}
+
+	// The option survives caching the response as JSON.
+	cached, err := json.Marshal(res)
+	checks.NoError(t, err, "Marshal error")
+	var restored openai.AudioResponse
+	checks.NoError(t, json.Unmarshal(cached, &restored), "Unmarshal error")
+	if merged := restored.MergeBySpeaker(); merged[0].Text != " Hello  world." {
+		t.Errorf("expected verbatim merged text after a JSON round-trip, got %q", merged[0].Text)
+	}
+
+	res, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Format:   openai.AudioResponseFormatVerboseJSON,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if merged := res.MergeBySpeaker(); merged[0].Text != "Hello world." {
+		t.Errorf("expected normalized merged text by default, got %q", merged[0].Text)
+	}
+}
+
+func TestVerboseTranslation(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/audio/translations", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("response_format") != string(openai.AudioResponseFormatVerboseJSON) {
+			http.Error(w, "expected verbose_json", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"task":"translate","language":"german","duration":2.5,"text":"Good morning.",` +
+			`"segments":[{"id":0,"start":0,"end":2.5,"text":" Good morning.","avg_logprob":-0.2}]}`))
+	})
+
+	res, err := client.CreateTranslation(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Format:   openai.AudioResponseFormatVerboseJSON,
+	})
+	checks.NoError(t, err, "CreateTranslation error")
+	if !res.IsTranslation() || res.SourceLanguage() != "german" || res.Duration != 2.5 {
+		t.Errorf("unexpected translation metadata: task=%q language=%q duration=%v", res.Task, res.Language, res.Duration)
+	}
+	if len(res.Segments) != 1 || res.Segments[0].Text != " Good morning." || res.Segments[0].End != 2.5 {
+		t.Errorf("translation dropped segments: %+v", res.Segments)
+	}
+}
+
+func TestVerboseTranslationWords(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/audio/translations", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		granularities := r.MultipartForm.Value["timestamp_granularities[]"]
+		if len(granularities) != 1 || granularities[0] != string(openai.TranscriptionTimestampGranularityWord) {
+			http.Error(w, "expected word granularity", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"task":"translate","language":"german","duration":1.2,"text":"Good morning.",` +
+			`"words":[{"word":"Good","start":0.1,"end":0.5},{"word":"morning","start":0.6,"end":1.1}]}`))
+	})
+
+	res, err := client.CreateTranslation(context.Background(), openai.AudioRequest{
+		Model:                  openai.Whisper1,
+		FilePath:               "audio.mp3",
+		Reader:                 strings.NewReader("audio"),
+		Format:                 openai.AudioResponseFormatVerboseJSON,
+		TimestampGranularities: []openai.TranscriptionTimestampGranularity{openai.TranscriptionTimestampGranularityWord},
+	})
+	checks.NoError(t, err, "CreateTranslation error")
+	want := []openai.AudioWord{{Word: "Good", Start: 0.1, End: 0.5}, {Word: "morning", Start: 0.6, End: 1.1}}
+	if !reflect.DeepEqual(res.Words, want) {
+		t.Errorf("translation dropped words: got %+v, want %+v", res.Words, want)
+	}
+}
+
+func TestAudioTimestampsUpgradeFormat(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var format string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		format = r.FormValue("response_format")
+		_, _ = w.Write([]byte(`{"text":"Hi","words":[{"word":"Hi","start":0,"end":0.4}]}`))
+	})
+
+	granularities := []openai.TranscriptionTimestampGranularity{openai.TranscriptionTimestampGranularityWord}
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:                  openai.Whisper1,
+		FilePath:               "audio.mp3",
+		Reader:                 strings.NewReader("audio"),
+		TimestampGranularities: granularities,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if format != string(openai.AudioResponseFormatVerboseJSON) || len(res.Words) != 1 {
+		t.Errorf("expected verbose_json with words, got format %q and words %v", format, res.Words)
+	}
+
+	for _, explicit := range []openai.AudioResponseFormat{openai.AudioResponseFormatJSON, openai.AudioResponseFormatSRT} {
+		_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:                  openai.Whisper1,
+			FilePath:               "audio.mp3",
+			Reader:                 strings.NewReader("audio"),
+			Format:                 explicit,
+			TimestampGranularities: granularities,
+		})
+		checks.ErrorIs(t, err, openai.ErrTimestampsRequireVerbose, "expected "+string(explicit)+" to be rejected")
+	}
+}
+
+func TestAudioWordsOnly(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var fields map[string][]string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		fields = r.MultipartForm.Value
+		_, _ = w.Write([]byte(`{"task":"transcribe","language":"english","duration":1.1,"text":"Good morning",
+			"segments":[{"id":0,"start":0,"end":1.1,"text":" Good morning"}],
+			"words":[{"word":"Good","start":0.1,"end":0.5},{"word":"morning","start":0.6,"end":1.1}]}`))
+	})
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:     openai.Whisper1,
+		FilePath:  "audio.mp3",
+		Reader:    strings.NewReader("audio"),
+		WordsOnly: true,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if got := fields["timestamp_granularities[]"]; !reflect.DeepEqual(got, []string{"word"}) {
+		t.Errorf("expected word granularity only, got %q", got)
+	}
+	if got := fields["response_format"]; !reflect.DeepEqual(got, []string{"verbose_json"}) {
+		t.Errorf("expected verbose_json, got %q", got)
+	}
+	if len(res.Segments) != 0 || res.Text != "" {
+		t.Errorf("expected no segments nor text, got %+v and %q", res.Segments, res.Text)
+	}
+	want := []openai.AudioWord{{Word: "Good", Start: 0.1, End: 0.5}, {Word: "morning", Start: 0.6, End: 1.1}}
+	if !reflect.DeepEqual(res.Words, want) || res.Duration != 1.1 || res.Language != "english" {
+		t.Errorf("unexpected response %+v", res)
+	}
+}
+
+func TestTranslationTargetLanguage(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var targets []string
+	server.RegisterHandler("/v1/audio/translations", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		targets = append(targets, strings.Join(r.MultipartForm.Value["target_language"], ","))
+		_, _ = w.Write([]byte(`{"text":"Bonjour."}`))
+	})
+
+	for _, target := range []string{"fr", ""} {
+		_, err := client.CreateTranslation(context.Background(), openai.AudioRequest{
+			Model:          openai.Whisper1,
+			FilePath:       "audio.mp3",
+			Reader:         strings.NewReader("audio"),
+			TargetLanguage: target,
+		})
+		checks.NoError(t, err, "CreateTranslation error")
+	}
+	if len(targets) != 2 || targets[0] != "fr" || targets[1] != "" {
+		t.Fatalf("expected target_language to be sent only when set, got %q", targets)
+	}
+}
+
+func TestTranslationTemperature(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	temperatures := make(map[string]string)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		temperatures[filepath.Base(r.URL.Path)] = r.FormValue("temperature")
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	}
+	server.RegisterHandler("/v1/audio/transcriptions", handler)
+	server.RegisterHandler("/v1/audio/translations", handler)
+
+	translationTemperature := float32(0.6)
+	template := openai.AudioRequest{
+		Model:                  openai.Whisper1,
+		FilePath:               "audio.mp3",
+		Temperature:            0.2,
+		TranslationTemperature: &translationTemperature,
+	}
+	request := template
+	request.Reader = strings.NewReader("audio")
+	_, err := client.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	request = template
+	request.Reader = strings.NewReader("audio")
+	_, err = client.CreateTranslation(context.Background(), request)
+	checks.NoError(t, err, "CreateTranslation error")
+
+	if temperatures["transcriptions"] != "0.20" || temperatures["translations"] != "0.60" {
+		t.Errorf("expected 0.20 for transcriptions and 0.60 for translations, got %v", temperatures)
+	}
+}
+
+func TestAudioSourceName(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+
+	cases := []struct {
+		request  openai.AudioRequest
+		expected string
+	}{
+		{openai.AudioRequest{FilePath: "calls/monday.mp3"}, "calls/monday.mp3"},
+		{openai.AudioRequest{FilePath: "audio.mp3", SourceName: "job-42"}, "job-42"},
+	}
+	for _, c := range cases {
+		c.request.Model = openai.Whisper1
+		c.request.Reader = strings.NewReader("audio")
+		res, err := client.CreateTranscription(context.Background(), c.request)
+		checks.NoError(t, err, "CreateTranscription error")
+		if res.SourceName != c.expected {
+			t.Errorf("expected SourceName %q, got %q", c.expected, res.SourceName)
+		}
+	}
+
+	res, err := client.CreateTranscriptionChunked(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "long.wav",
+		Reader:   bytes.NewReader(silentWAV(2 * time.Second)),
+	}, openai.TranscriptionChunkOptions{ChunkDuration: time.Second})
+	checks.NoError(t, err, "CreateTranscriptionChunked error")
+	if res.SourceName != "long.wav" {
+		t.Errorf("expected the chunked result to be named after the input, got %q", res.SourceName)
+	}
+}
+
+func TestNewAudioRequestFromMultipart(t *testing.T) {
+	newPart := func(filename string) *multipart.Part {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile("audio", filename)
+		checks.NoErrorF(t, err, "CreateFormFile error")
+		_, _ = fw.Write([]byte("wav bytes"))
+		checks.NoErrorF(t, mw.Close(), "Close error")
+
+		part, err := multipart.NewReader(&body, mw.Boundary()).NextPart()
+		checks.NoErrorF(t, err, "NextPart error")
+		return part
+	}
+
+	req, err := openai.NewAudioRequestFromMultipart(newPart("meeting.WAV"))
+	checks.NoErrorF(t, err, "NewAudioRequestFromMultipart error")
+	if req.Model != openai.Whisper1 || req.FilePath != "meeting.WAV" {
+		t.Errorf("unexpected request %+v", req)
+	}
+	content, err := io.ReadAll(req.Reader)
+	checks.NoError(t, err, "ReadAll error")
+	if string(content) != "wav bytes" {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	_, err = openai.NewAudioRequestFromMultipart(newPart("notes.txt"))
+	checks.ErrorIs(t, err, openai.ErrAudioUnsupportedFileExt, "expected unsupported extension error")
+}
+
+func TestAudioAcceptHeader(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var accept string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`{"text":"hi"}`))
+	})
+
+	testcases := []struct {
+		format openai.AudioResponseFormat
+		accept string
+	}{
+		{"", "application/json"},
+		{openai.AudioResponseFormatJSON, "application/json"},
+		{openai.AudioResponseFormatVerboseJSON, "application/json"},
+		{openai.AudioResponseFormatText, "text/plain"},
+		{openai.AudioResponseFormatSRT, "application/x-subrip"},
+		{openai.AudioResponseFormatVTT, "text/vtt"},
+	}
+	for _, tc := range testcases {
+		_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:    openai.Whisper1,
+			FilePath: "audio.mp3",
+			Reader:   strings.NewReader("audio"),
+			Format:   tc.format,
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+		if accept != tc.accept {
+			t.Errorf("format %q: expected Accept %q, got %q", tc.format, tc.accept, accept)
+		}
+	}
+}
+
+func TestAudioResponseFormatExtension(t *testing.T) {
+	testcases := []struct {
+		format    openai.AudioResponseFormat
+		extension string
+		mimeType  string
+	}{
+		{openai.AudioResponseFormatJSON, ".json", "application/json"},
+		{openai.AudioResponseFormatVerboseJSON, ".json", "application/json"},
+		{openai.AudioResponseFormatText, ".txt", "text/plain"},
+		{openai.AudioResponseFormatSRT, ".srt", "application/x-subrip"},
+		{openai.AudioResponseFormatVTT, ".vtt", "text/vtt"},
+		{"", "", ""},
+		{"tsv", "", ""},
+	}
+	for _, tc := range testcases {
+		if got := tc.format.Extension(); got != tc.extension {
+			t.Errorf("%q: expected extension %q, got %q", tc.format, tc.extension, got)
+		}
+		if got := tc.format.MIMEType(); got != tc.mimeType {
+			t.Errorf("%q: expected MIME type %q, got %q", tc.format, tc.mimeType, got)
+		}
+	}
+}
+
+func TestAudioDataURI(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var filename, contentType, content string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		filename, contentType, content = header.Filename, header.Header.Get("Content-Type"), string(data)
+		_, _ = w.Write([]byte(`{"text":"hi"}`))
+	})
+
+	uri := "data:audio/wav;base64," + base64.StdEncoding.EncodeToString([]byte("RIFF fake wav"))
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:   openai.Whisper1,
+		DataURI: uri,
+	})
+	checks.NoErrorF(t, err, "CreateTranscription error")
+	if filename != "audio.wav" || contentType != "audio/wav" || content != "RIFF fake wav" {
+		t.Errorf("unexpected upload: filename=%q content-type=%q content=%q", filename, contentType, content)
+	}
+
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "call.wav",
+		DataURI:  uri,
+	})
+	checks.NoErrorF(t, err, "CreateTranscription error")
+	if filename != "call.wav" {
+		t.Errorf("expected FilePath to name the upload, got %q", filename)
+	}
+
+	for _, malformed := range []string{
+		"audio/wav;base64,AAAA",
+		"data:audio/wav;base64",
+		"data:audio/wav,plain",
+		"data:;base64,AAAA",
+		"data:audio/wav;base64,not base64!",
+	} {
+		_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:   openai.Whisper1,
+			DataURI: malformed,
+		})
+		checks.ErrorIs(t, err, openai.ErrInvalidDataURI, "expected ErrInvalidDataURI for "+malformed)
+	}
+}
+
+func TestAudioProcessingTime(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var processingTime string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		if processingTime != "" {
+			w.Header().Set(openai.ProcessingTimeHeader, processingTime)
+		}
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+
+	cases := map[string]time.Duration{
+		"1250":  1250 * time.Millisecond,
+		"12.5":  12500 * time.Microsecond,
+		"1.5s":  1500 * time.Millisecond,
+		"":      0,
+		"soon":  0,
+		"-20ms": 0,
+		"-20":   0,
+	}
+	for header, expected := range cases {
+		processingTime = header
+		res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:    openai.Whisper1,
+			FilePath: "audio.mp3",
+			Reader:   strings.NewReader("audio"),
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+		if got := res.ProcessingTime(); got != expected {
+			t.Errorf("%s %q: expected %v, got %v", openai.ProcessingTimeHeader, header, expected, got)
+		}
+	}
+}
+
+func TestAudioFormatResolver(t *testing.T) {
+	server := test.NewTestServer()
+	var contentTypes []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		contentTypes = append(contentTypes, header.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.AudioFormatResolver = func(filename string) (string, bool) {
+		if filepath.Ext(filename) == ".m4b" {
+			return "audio/mp4", true
+		}
+		return "", false
+	}
+	client := openai.NewClientWithConfig(config)
+
+	for _, filename := range []string{"book.m4b", "audio.mp3"} {
+		_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:    openai.Whisper1,
+			FilePath: filename,
+			Reader:   strings.NewReader("audio"),
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+	}
+	if len(contentTypes) != 2 || contentTypes[0] != "audio/mp4" || contentTypes[1] != "" {
+		t.Errorf("expected the resolved type for .m4b only, got %q", contentTypes)
+	}
+}
+
+func TestAudioLocale(t *testing.T) {
+	server := test.NewTestServer()
+	var languages []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.Header.Get("Accept-Language"))
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.Header.Get("Accept-Language"))
+		_, _ = w.Write([]byte("audio"))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken()).WithLocale("fr-FR")
+	config.BaseURL = ts.URL + "/v1"
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	speech, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Bonjour",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoError(t, err, "CreateSpeech error")
+	speech.Close()
+	if len(languages) != 2 || languages[0] != "fr-FR" || languages[1] != "fr-FR" {
+		t.Errorf("expected Accept-Language fr-FR on both requests, got %q", languages)
+	}
+}
+
+func TestAudioResponseHeaders(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	const proxyHeader = "X-Proxy-Upstream"
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(xCustomHeader, xCustomHeaderValue)
+		w.Header().Set(proxyHeader, "eu-west")
+		if r.FormValue("response_format") == string(openai.AudioResponseFormatText) {
+			_, _ = w.Write([]byte("hello"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(xCustomHeader, xCustomHeaderValue)
+		_, _ = w.Write([]byte("mp3"))
+	})
+
+	for _, format := range []openai.AudioResponseFormat{openai.AudioResponseFormatJSON, openai.AudioResponseFormatText} {
+		res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:    openai.Whisper1,
+			FilePath: "audio.mp3",
+			Reader:   strings.NewReader("audio"),
+			Format:   format,
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+		if res.Header().Get(xCustomHeader) != xCustomHeaderValue || res.Header().Get(proxyHeader) != "eu-west" {
+			t.Errorf("%s: custom headers missing from %v", format, res.Header())
+		}
+	}
+
+	speech, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoError(t, err, "CreateSpeech error")
+	defer speech.Close()
+	if speech.Header().Get(xCustomHeader) != xCustomHeaderValue {
+		t.Errorf("custom header missing from speech response: %v", speech.Header())
+	}
+}
+
+func TestAudioStrictJSON(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"text":"hello","confidence":0.98}`))
+	})
+
+	request := openai.AudioRequest{Model: openai.Whisper1, FilePath: "audio.mp3"}
+	for _, strict := range []bool{false, true} {
+		config := openai.DefaultConfig(test.GetTestToken())
+		config.BaseURL = ts.URL + "/v1"
+		config.StrictJSON = strict
+		client := openai.NewClientWithConfig(config)
+
+		request.Reader = strings.NewReader("audio")
+		res, err := client.CreateTranscription(context.Background(), request)
+		if strict {
+			if err == nil || !strings.Contains(err.Error(), "confidence") {
+				t.Fatalf("expected the unknown field to be rejected in strict mode, got %v", err)
+			}
+			continue
+		}
+		checks.NoError(t, err, "CreateTranscription error")
+		if res.Text != "hello" {
+			t.Errorf("unexpected text: %q", res.Text)
+		}
+	}
+}
+
+func TestAudioRawPCMFormatFields(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var fields map[string][]string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		fields = r.MultipartForm.Value
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+
+	request := openai.AudioRequest{
+		Model:           openai.Whisper1,
+		FilePath:        "capture.pcm",
+		Reader:          bytes.NewReader(make([]byte, 320)),
+		InputSampleRate: 16000,
+		InputChannels:   1,
+	}
+	_, err := client.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	for name, want := range map[string]string{"sample_rate": "16000", "channels": "1", "encoding": "pcm_s16le"} {
+		if got := strings.Join(fields[name], ","); got != want {
+			t.Errorf("field %s: expected %q, got %q", name, want, got)
+		}
+	}
+
+	request.FilePath = "audio.mp3"
+	request.Reader = strings.NewReader("audio")
+	_, err = client.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	if _, ok := fields["sample_rate"]; ok {
+		t.Error("PCM fields should only be sent for raw PCM input")
+	}
+
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "capture.raw",
+		Reader:   bytes.NewReader(make([]byte, 320)),
+	})
+	checks.ErrorIs(t, err, openai.ErrPCMFormatRequired, "raw PCM without a sample format should be rejected")
+}
@@ -0,0 +1,162 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrChannelSplitUnsupportedFormat  = errors.New("per-channel transcription only supports WAV input")
+	ErrChannelSelectUnsupportedFormat = errors.New("channel selection only supports WAV or pcm_s16le raw PCM input")
+	ErrInvalidChannelSelect           = errors.New("selected channel is out of range")
+)
+
+// ChannelSpeakerLabel returns the speaker label given to the segments of a channel by
+// CreateTranscriptionPerChannel when no label is provided, e.g. "channel_0" for the left channel.
+func ChannelSpeakerLabel(channel int) string {
+	return fmt.Sprintf("channel_%d", channel)
+}
+
+// CreateTranscriptionPerChannel — transcribes each channel of a multi-channel WAV recording as a
+// distinct speaker, e.g. a call recorded with the agent on the left and the customer on the right.
+// Channels are split in memory and uploaded as mono audio. speakers[i] is set as the Speaker of
+// the segments and words of channel i, missing labels default to ChannelSpeakerLabel(i). Segments
+// and words of all channels are merged by start time; the response format is always verbose_json
+// so timings are available.
+func (c *Client) CreateTranscriptionPerChannel(
+	ctx context.Context,
+	request AudioRequest,
+	speakers []string,
+) (response AudioResponse, err error) {
+	wav, err := readWAVInput(request, ErrChannelSplitUnsupportedFormat)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+	if wav, err = request.trimWAV(wav); err != nil {
+		return AudioResponse{}, err
+	}
+	offset := request.StartOffset.Seconds()
+	request.StartOffset, request.EndOffset = 0, 0
+
+	name := strings.TrimSuffix(filepath.Base(request.FilePath), filepath.Ext(request.FilePath))
+	request.SourceName = request.sourceName()
+	channels := make([]AudioResponse, wav.Channels)
+	for channel := range channels {
+		channelRequest := request
+		channelRequest.FilePath = fmt.Sprintf("%s-ch%d.wav", name, channel)
+		channelRequest.Reader = bytes.NewReader(wav.channel(channel).encode())
+		channelRequest.DataURI = ""
+		channelRequest.Compressed = false
+		channelRequest.Format = AudioResponseFormatVerboseJSON
+
+		var transcript AudioResponse
+		transcript, err = c.CreateTranscription(ctx, channelRequest)
+		if err != nil {
+			return AudioResponse{}, fmt.Errorf("transcribing channel %d: %w", channel, err)
+		}
+		speaker := ChannelSpeakerLabel(channel)
+		if channel < len(speakers) && speakers[channel] != "" {
+			speaker = speakers[channel]
+		}
+		for i := range transcript.Segments {
+			transcript.Segments[i].Speaker = speaker
+		}
+		for i := range transcript.Words {
+			transcript.Words[i].Speaker = speaker
+		}
+		channels[channel] = transcript.withOffset(offset)
+	}
+	return mergeChannelResponses(channels), nil
+}
+
+// selectChannelRequest returns a copy of the request whose input only holds the channel selected by
+// InputChannelSelect, as mono audio, with InputChannelSelect cleared.
+func selectChannelRequest(request AudioRequest) (AudioRequest, error) {
+	channel := *request.InputChannelSelect
+	rawPCM := request.isRawPCM()
+	selected, err := selectChannelFields(request)
+	if err != nil {
+		return AudioRequest{}, err
+	}
+	data, err := readAudioInput(request)
+	if err != nil {
+		return AudioRequest{}, err
+	}
+
+	var wav wavAudio
+	switch {
+	case rawPCM:
+		wav = wavAudio{Channels: request.InputChannels, SampleRate: request.InputSampleRate, BitsPerSample: 16, Data: data}
+	case isWAV(data):
+		if wav, err = parseWAV(data); err != nil {
+			return AudioRequest{}, err
+		}
+	default:
+		return AudioRequest{}, ErrChannelSelectUnsupportedFormat
+	}
+	if channel < 0 || channel >= wav.Channels {
+		return AudioRequest{}, fmt.Errorf("%w: channel %d of %d", ErrInvalidChannelSelect, channel, wav.Channels)
+	}
+
+	mono := wav.channel(channel)
+	if rawPCM {
+		selected.Reader = bytes.NewReader(mono.Data)
+	} else {
+		selected.Reader = bytes.NewReader(mono.encode())
+	}
+	selected.DataURI = ""
+	selected.FilePath, selected.Compressed = request.uploadFilename(), false
+	selected.InputChannelSelect = nil
+	return selected, nil
+}
+
+// selectChannelFields applies the form field changes of InputChannelSelect without reading the
+// input: raw PCM is uploaded as mono. Only raw PCM declares its channel count, so an out-of-range
+// channel of other input is only detected once the input is read.
+func selectChannelFields(request AudioRequest) (AudioRequest, error) {
+	if !request.isRawPCM() {
+		return request, nil
+	}
+	if request.InputSampleRate <= 0 || request.InputChannels <= 0 {
+		return AudioRequest{}, ErrPCMFormatRequired
+	}
+	if request.InputEncoding != "" && request.InputEncoding != defaultPCMEncoding {
+		return AudioRequest{}, fmt.Errorf("%w, got %s", ErrChannelSelectUnsupportedFormat, request.InputEncoding)
+	}
+	if channel := *request.InputChannelSelect; channel < 0 || channel >= request.InputChannels {
+		return AudioRequest{}, fmt.Errorf("%w: channel %d of %d", ErrInvalidChannelSelect, channel, request.InputChannels)
+	}
+	request.InputChannels = 1
+	return request, nil
+}
+
+// mergeChannelResponses interleaves simultaneous channel responses by start time.
+// Usage is the sum of all channels, as each one is billed separately.
+func mergeChannelResponses(channels []AudioResponse) AudioResponse {
+	merged := mergeAudioResponses(channels)
+	merged.Duration = 0
+	for _, channel := range channels {
+		if channel.Duration > merged.Duration {
+			merged.Duration = channel.Duration
+		}
+	}
+
+	sort.SliceStable(merged.Segments, func(i, j int) bool {
+		return merged.Segments[i].Start < merged.Segments[j].Start
+	})
+	sort.SliceStable(merged.Words, func(i, j int) bool {
+		return merged.Words[i].Start < merged.Words[j].Start
+	})
+	for i := range merged.Segments {
+		merged.Segments[i].ID = i
+	}
+	if len(merged.Segments) > 0 {
+		merged.Text = merged.segmentsText(merged.Segments)
+	}
+	return merged
+}
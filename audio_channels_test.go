@@ -0,0 +1,145 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateTranscriptionPerChannel(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	// Left channel: silence then a positive tone, right channel: a negative tone then silence.
+	samples := make([]int16, 0, 2*8000)
+	for i := 0; i < 8000; i++ {
+		left, right := int16(0), int16(-100)
+		if i >= 4000 {
+			left, right = 100, 0
+		}
+		samples = append(samples, left, right)
+	}
+
+	responses := map[string]string{
+		"call-ch0.wav": `{"duration":1,"text":"How can I help?",` +
+			`"segments":[{"id":0,"start":0.5,"end":0.9,"text":" How can I help?"}],` +
+			`"words":[{"word":"How","start":0.5,"end":0.6}]}`,
+		"call-ch1.wav": `{"duration":1,"text":"Hi, my order is late.",` +
+			`"segments":[{"id":0,"start":0.0,"end":0.4,"text":" Hi, my order is late."}],` +
+			`"words":[{"word":"Hi","start":0.0,"end":0.1}]}`,
+	}
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if r.FormValue("response_format") != string(openai.AudioResponseFormatVerboseJSON) {
+			t.Errorf("expected verbose_json, got %q", r.FormValue("response_format"))
+		}
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(file)
+		if channels := buf.Bytes()[22]; channels != 1 {
+			t.Errorf("%s: expected mono audio, got %d channels", header.Filename, channels)
+		}
+		_, _ = w.Write([]byte(responses[header.Filename]))
+	})
+
+	res, err := client.CreateTranscriptionPerChannel(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "call.wav",
+		Reader:   bytes.NewReader(testWAV(8000, 2, samples)),
+	}, []string{"agent"})
+	checks.NoError(t, err, "CreateTranscriptionPerChannel error")
+
+	if len(res.Segments) != 2 {
+		t.Fatalf("expected 2 segments, got %d", len(res.Segments))
+	}
+	if res.Segments[0].Speaker != openai.ChannelSpeakerLabel(1) || res.Segments[1].Speaker != "agent" {
+		t.Errorf("unexpected speakers: %q, %q", res.Segments[0].Speaker, res.Segments[1].Speaker)
+	}
+	if res.Segments[0].ID != 0 || res.Segments[1].ID != 1 {
+		t.Errorf("segments were not renumbered: %+v", res.Segments)
+	}
+	if len(res.Words) != 2 || res.Words[0].Speaker != openai.ChannelSpeakerLabel(1) || res.Words[1].Speaker != "agent" {
+		t.Errorf("words are not labeled with their channel: %+v", res.Words)
+	}
+	if res.Text != "Hi, my order is late. How can I help?" {
+		t.Errorf("unexpected text: %q", res.Text)
+	}
+	if res.Duration != 1 {
+		t.Errorf("expected the longest channel duration, got %v", res.Duration)
+	}
+}
+
+func TestInputChannelSelect(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var uploaded []byte
+	var channels string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, _ := r.FormFile("file")
+		uploaded, _ = io.ReadAll(file)
+		channels = r.FormValue("channels")
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	stereo := testWAV(8000, 2, []int16{1, -1, 2, -2, 3, -3})
+	right := 1
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:              openai.Whisper1,
+		FilePath:           "call.wav",
+		Reader:             bytes.NewReader(stereo),
+		InputChannelSelect: &right,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if expected := testWAV(8000, 1, []int16{-1, -2, -3}); !bytes.Equal(uploaded, expected) {
+		t.Errorf("expected the right channel as mono WAV, got % x", uploaded)
+	}
+
+	left := 0
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:              openai.Whisper1,
+		FilePath:           "call.pcm",
+		Reader:             bytes.NewReader(stereo[44:]),
+		InputSampleRate:    8000,
+		InputChannels:      2,
+		InputChannelSelect: &left,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if expected := testWAV(8000, 1, []int16{1, 2, 3})[44:]; !bytes.Equal(uploaded, expected) || channels != "1" {
+		t.Errorf("expected the left channel as mono PCM, got % x with %s channels", uploaded, channels)
+	}
+
+	missing := 2
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:              openai.Whisper1,
+		FilePath:           "call.wav",
+		Reader:             bytes.NewReader(stereo),
+		InputChannelSelect: &missing,
+	})
+	checks.ErrorIs(t, err, openai.ErrInvalidChannelSelect, "expected an out-of-range channel to be rejected")
+}
+
+func TestInputChannelSelectPCMFormatRequired(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("expected no request to be sent")
+	})
+	left := 0
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:              openai.Whisper1,
+		FilePath:           "call.pcm",
+		Reader:             bytes.NewReader(make([]byte, 8)),
+		InputChannels:      2,
+		InputChannelSelect: &left,
+	})
+	checks.ErrorIs(t, err, openai.ErrPCMFormatRequired, "expected the missing sample rate to be reported")
+}
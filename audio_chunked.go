@@ -0,0 +1,269 @@
+package openai
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrChunkingUnsupportedFormat = errors.New("chunked transcription only supports WAV input")
+)
+
+const (
+	defaultTranscriptionChunkDuration = 10 * time.Minute
+	// uploadFormOverhead is the room left for the other form fields and the multipart framing when
+	// chunks are sized to fit ClientConfig.MaxUploadBytes.
+	uploadFormOverhead = 64 << 10
+)
+
+// TranscriptionChunkOptions configures CreateTranscriptionChunked.
+type TranscriptionChunkOptions struct {
+	// ChunkDuration is the length of each uploaded chunk. Defaults to 10 minutes. Chunks are
+	// shortened as needed to keep every upload under ClientConfig.MaxUploadBytes.
+	ChunkDuration time.Duration
+
+	// ChunkOverride, when set, is called for every chunk and may return a language and a prompt
+	// to use for that chunk instead of the request's. Empty values fall back to the request.
+	ChunkOverride func(index int) (language, prompt string)
+
+	// Concurrency is the number of chunks transcribed at the same time. Defaults to 1.
+	Concurrency int
+
+	// OnChunk, when set, is called with the response of every chunk as soon as it completes, with
+	// timings already shifted, e.g. to add its segments to an IncrementalTranscriptFile. With a
+	// Concurrency above 1, chunks may complete out of order and OnChunk is called concurrently.
+	OnChunk func(index int, chunk AudioResponse)
+}
+
+// CreateTranscriptionChunked — transcribes long audio by splitting it into chunks that are sent
+// as separate transcription requests. Segment and word timings of every chunk are shifted by the
+// chunk's offset and merged into a single response. Only WAV input is supported.
+// StartOffset and EndOffset of the request select the range that is split into chunks.
+//
+// When ctx is canceled or a chunk fails, no further chunk is sent, the chunks in flight are
+// awaited, and the merged response of the chunks that completed is returned with the error.
+func (c *Client) CreateTranscriptionChunked(
+	ctx context.Context,
+	request AudioRequest,
+	options TranscriptionChunkOptions,
+) (response AudioResponse, err error) {
+	wav, err := readWAVInput(request, ErrChunkingUnsupportedFormat)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+	if wav, err = request.trimWAV(wav); err != nil {
+		return AudioResponse{}, err
+	}
+	offset := request.StartOffset.Seconds()
+	request.StartOffset, request.EndOffset = 0, 0
+
+	chunkDuration := options.ChunkDuration
+	if chunkDuration <= 0 {
+		chunkDuration = defaultTranscriptionChunkDuration
+	}
+	framesPerChunk := int(chunkDuration * time.Duration(wav.SampleRate) / time.Second)
+	if limit := c.config.MaxUploadBytes; limit > 0 {
+		maxFrames := int((limit - uploadFormOverhead - wavHeaderSize) / int64(wav.blockAlign()))
+		if maxFrames <= 0 {
+			return AudioResponse{}, fmt.Errorf("%w: MaxUploadBytes of %d leaves no room for audio", ErrUploadTooLarge, limit)
+		}
+		framesPerChunk = minInt(framesPerChunk, maxFrames)
+	}
+	if framesPerChunk <= 0 {
+		framesPerChunk = 1
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	chunkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		chunkErr error
+		// chunks[i] is set once chunk i completed.
+		chunks = make([]*AudioResponse, (wav.frames()+framesPerChunk-1)/framesPerChunk)
+	)
+	semaphore := make(chan struct{}, concurrency)
+	name := strings.TrimSuffix(filepath.Base(request.FilePath), filepath.Ext(request.FilePath))
+	request.SourceName = request.sourceName()
+	for index, start := 0, 0; start < wav.frames(); index, start = index+1, start+framesPerChunk {
+		select {
+		case semaphore <- struct{}{}:
+		case <-chunkCtx.Done():
+		}
+		if chunkCtx.Err() != nil {
+			break
+		}
+
+		chunkRequest := request
+		chunkRequest.FilePath = fmt.Sprintf("%s-%d.wav", name, index)
+		chunkRequest.Reader = bytes.NewReader(wav.slice(start, start+framesPerChunk).encode())
+		chunkRequest.DataURI = ""
+		chunkRequest.Compressed = false
+		if options.ChunkOverride != nil {
+			language, prompt := options.ChunkOverride(index)
+			if language != "" {
+				chunkRequest.Language = language
+			}
+			if prompt != "" {
+				chunkRequest.Prompt = prompt
+			}
+		}
+
+		chunkOffset := offset + float64(start)/float64(wav.SampleRate)
+		wg.Add(1)
+		go func(index int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			chunk, err := c.CreateTranscription(chunkCtx, chunkRequest)
+			if err != nil {
+				mu.Lock()
+				if chunkErr == nil {
+					chunkErr = fmt.Errorf("transcribing chunk %d: %w", index, err)
+				}
+				mu.Unlock()
+				cancel()
+				return
+			}
+			chunk = chunk.withOffset(chunkOffset)
+			chunks[index] = &chunk
+			if options.OnChunk != nil {
+				options.OnChunk(index, chunk)
+			}
+		}(index)
+	}
+	wg.Wait()
+
+	var completed []AudioResponse
+	for _, chunk := range chunks {
+		if chunk != nil {
+			completed = append(completed, *chunk)
+		}
+	}
+	response = mergeAudioResponses(completed)
+	switch {
+	case ctx.Err() != nil:
+		return response, ctx.Err()
+	case chunkErr != nil:
+		return response, chunkErr
+	}
+	return response, nil
+}
+
+// readWAVInput reads the whole request input and decodes it as WAV, returning unsupported
+// when the input is in another format.
+func readWAVInput(request AudioRequest, unsupported error) (wavAudio, error) {
+	data, err := readAudioInput(request)
+	if err != nil {
+		return wavAudio{}, err
+	}
+	if !isWAV(data) {
+		return wavAudio{}, unsupported
+	}
+	return parseWAV(data)
+}
+
+// readAudioInput reads the whole request input into memory, decompressing it when needed.
+func readAudioInput(request AudioRequest) ([]byte, error) {
+	reader := request.Reader
+	if request.DataURI != "" {
+		audio, err := decodeAudioDataURI(request.DataURI)
+		if err != nil {
+			return nil, err
+		}
+		reader = audio
+	}
+	if reader == nil {
+		f, err := os.Open(request.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("opening audio file: %w", err)
+		}
+		defer f.Close()
+		reader = f
+	}
+	if request.isCompressed() && request.DataURI == "" {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing audio: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading audio: %w", err)
+	}
+	return data, nil
+}
+
+// withOffset returns a copy of the response with all timings shifted by offset seconds.
+func (r AudioResponse) withOffset(offset float64) AudioResponse {
+	if r.Segments != nil {
+		segments := make([]AudioSegment, len(r.Segments))
+		for i, segment := range r.Segments {
+			segment.Start += offset
+			segment.End += offset
+			segments[i] = segment
+		}
+		r.Segments = segments
+	}
+	if r.Words != nil {
+		words := make([]AudioWord, len(r.Words))
+		for i, word := range r.Words {
+			word.Start += offset
+			word.End += offset
+			words[i] = word
+		}
+		r.Words = words
+	}
+	return r
+}
+
+// mergeAudioResponses concatenates consecutive chunk responses into one, renumbering segments.
+func mergeAudioResponses(chunks []AudioResponse) AudioResponse {
+	var merged AudioResponse
+	var texts []string
+	for i, chunk := range chunks {
+		if i == 0 {
+			merged.Task = chunk.Task
+			merged.Language = chunk.Language
+			merged.PreserveWhitespace = chunk.PreserveWhitespace
+			merged.SourceName = chunk.SourceName
+			merged.httpHeader = chunk.httpHeader
+		}
+		merged.Duration += chunk.Duration
+		for _, segment := range chunk.Segments {
+			segment.ID = len(merged.Segments)
+			merged.Segments = append(merged.Segments, segment)
+		}
+		merged.Words = append(merged.Words, chunk.Words...)
+		merged.Warnings = append(merged.Warnings, chunk.Warnings...)
+		if text := strings.TrimSpace(chunk.Text); text != "" {
+			texts = append(texts, text)
+		}
+		if chunk.Usage != nil {
+			if merged.Usage == nil {
+				merged.Usage = &AudioResponseUsage{Type: chunk.Usage.Type}
+			}
+			merged.Usage.Seconds += chunk.Usage.Seconds
+		}
+	}
+	merged.Text = strings.Join(texts, " ")
+	return merged
+}
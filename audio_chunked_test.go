@@ -0,0 +1,260 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// testWAV returns a 16-bit PCM WAV file containing the given interleaved samples.
+func testWAV(sampleRate, channels int, samples []int16) []byte {
+	var buf bytes.Buffer
+	dataSize := len(samples) * 2
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, []uint32{16})
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{1, uint16(channels)})
+	_ = binary.Write(&buf, binary.LittleEndian, []uint32{uint32(sampleRate), uint32(sampleRate * channels * 2)})
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{uint16(channels * 2), 16})
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	_ = binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+// silentWAV returns a mono 16-bit WAV of the given duration at 8kHz.
+func silentWAV(d time.Duration) []byte {
+	return testWAV(8000, 1, make([]int16, int(d.Seconds()*8000)))
+}
+
+type chunkRequest struct {
+	language string
+	prompt   string
+	filename string
+}
+
+func registerChunkHandler(server *test.ServerTest) (*sync.Mutex, *[]chunkRequest) {
+	var mu sync.Mutex
+	var received []chunkRequest
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		index := len(received)
+		received = append(received, chunkRequest{r.FormValue("language"), r.FormValue("prompt"), header.Filename})
+		mu.Unlock()
+		fmt.Fprintf(w, `{"language":"english","duration":1,"text":"chunk %d",`+
+			`"segments":[{"id":0,"start":0.1,"end":0.9,"text":" chunk %d"}],"words":[{"word":"chunk","start":0.1,"end":0.5}]}`,
+			index, index)
+	})
+	return &mu, &received
+}
+
+func TestCreateTranscriptionChunked(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	_, received := registerChunkHandler(server)
+
+	res, err := client.CreateTranscriptionChunked(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "meeting.wav",
+		Reader:   bytes.NewReader(silentWAV(3 * time.Second)),
+		Format:   openai.AudioResponseFormatVerboseJSON,
+		Language: "en",
+		Prompt:   "base prompt",
+	}, openai.TranscriptionChunkOptions{
+		ChunkDuration: time.Second,
+		ChunkOverride: func(index int) (string, string) {
+			if index == 1 {
+				return "fr", "bonjour"
+			}
+			return "", ""
+		},
+	})
+	checks.NoErrorF(t, err, "CreateTranscriptionChunked error")
+
+	expected := []chunkRequest{
+		{"en", "base prompt", "meeting-0.wav"},
+		{"fr", "bonjour", "meeting-1.wav"},
+		{"en", "base prompt", "meeting-2.wav"},
+	}
+	if len(*received) != len(expected) {
+		t.Fatalf("expected %d chunk requests, got %d", len(expected), len(*received))
+	}
+	for i, want := range expected {
+		if (*received)[i] != want {
+			t.Errorf("chunk %d: expected %+v, got %+v", i, want, (*received)[i])
+		}
+	}
+
+	if res.Text != "chunk 0 chunk 1 chunk 2" || res.Duration != 3 || res.Language != "english" {
+		t.Errorf("unexpected merged response: %+v", res)
+	}
+	for i, segment := range res.Segments {
+		if segment.ID != i || segment.Start != float64(i)+0.1 || segment.End != float64(i)+0.9 {
+			t.Errorf("unexpected segment %d: %+v", i, segment)
+		}
+	}
+	if len(res.Words) != 3 || res.Words[2].Start != 2.1 {
+		t.Errorf("unexpected words %+v", res.Words)
+	}
+}
+
+func TestCreateTranscriptionChunkedUnsupportedFormat(t *testing.T) {
+	client := openai.NewClient("test-token")
+	_, err := client.CreateTranscriptionChunked(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("ID3 mp3 data"),
+	}, openai.TranscriptionChunkOptions{})
+	if !errors.Is(err, openai.ErrChunkingUnsupportedFormat) {
+		t.Fatalf("expected ErrChunkingUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestCreateTranscriptionMaxUploadBytes(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	// Room for one second of 8kHz 16-bit mono audio per upload.
+	config.MaxUploadBytes = 64<<10 + 44 + 16000
+	client := openai.NewClientWithConfig(config)
+
+	var sizes []int64
+	var mu sync.Mutex
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sizes = append(sizes, r.ContentLength)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	request := openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "long.wav",
+		Reader:   bytes.NewReader(silentWAV(10 * time.Second)),
+	}
+
+	_, err := client.CreateTranscription(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrUploadTooLarge, "expected the oversized upload to be rejected")
+	if !strings.Contains(err.Error(), "CreateTranscriptionChunked") {
+		t.Errorf("expected guidance towards the chunked API, got %v", err)
+	}
+	if len(sizes) != 0 {
+		t.Fatalf("expected nothing to be sent, got %d requests", len(sizes))
+	}
+
+	request.Reader = bytes.NewReader(silentWAV(10 * time.Second))
+	_, err = client.CreateTranscriptionChunked(context.Background(), request, openai.TranscriptionChunkOptions{})
+	checks.NoError(t, err, "CreateTranscriptionChunked error")
+	if len(sizes) != 10 {
+		t.Fatalf("expected 10 one-second chunks, got %d", len(sizes))
+	}
+	for _, size := range sizes {
+		if size > config.MaxUploadBytes {
+			t.Errorf("chunk of %d bytes exceeds the cap of %d", size, config.MaxUploadBytes)
+		}
+	}
+}
+
+func TestCreateTranscriptionChunkedCancel(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+	var calls int32
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		// Consume the upload so the server notices when the client goes away.
+		_, _ = io.Copy(io.Discard, r.Body)
+		if atomic.AddInt32(&calls, 1) == 2 {
+			// Cancel once the first chunk completed, while the second one is in flight.
+			cancel()
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		fmt.Fprint(w, `{"duration":1,"text":"first","segments":[{"id":0,"start":0.1,"end":0.9,"text":" first"}]}`)
+	})
+
+	res, err := client.CreateTranscriptionChunked(ctx, openai.AudioRequest{
+		Model:       openai.Whisper1,
+		FilePath:    "meeting.wav",
+		Reader:      bytes.NewReader(silentWAV(4 * time.Second)),
+		StartOffset: time.Second,
+	}, openai.TranscriptionChunkOptions{ChunkDuration: time.Second})
+	checks.ErrorIs(t, err, context.Canceled, "expected context.Canceled")
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("expected dispatch to stop after cancellation, got %d calls", n)
+	}
+	if res.Text != "first" || len(res.Segments) != 1 {
+		t.Fatalf("expected the completed chunk to be returned, got %+v", res)
+	}
+	if segment := res.Segments[0]; segment.Start != 1.1 || segment.End != 1.9 {
+		t.Errorf("completed chunk lost its offset: %+v", segment)
+	}
+}
+
+func TestCreateTranscriptionChunkedConcurrency(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		_, header, _ := r.FormFile("file")
+		fmt.Fprintf(w, `{"duration":1,"text":"%s","segments":[{"id":0,"start":0,"end":1,"text":" %s"}]}`,
+			header.Filename, header.Filename)
+	})
+
+	res, err := client.CreateTranscriptionChunked(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "meeting.wav",
+		Reader:   bytes.NewReader(silentWAV(5 * time.Second)),
+	}, openai.TranscriptionChunkOptions{ChunkDuration: time.Second, Concurrency: 2})
+	checks.NoError(t, err, "CreateTranscriptionChunked error")
+	if maxInFlight != 2 {
+		t.Errorf("expected 2 chunks in flight at most, got %d", maxInFlight)
+	}
+	if res.Text != "meeting-0.wav meeting-1.wav meeting-2.wav meeting-3.wav meeting-4.wav" {
+		t.Errorf("chunks merged out of order: %q", res.Text)
+	}
+	for i, segment := range res.Segments {
+		if segment.Start != float64(i) {
+			t.Errorf("segment %d has start %v", i, segment.Start)
+		}
+	}
+}
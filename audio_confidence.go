@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"math"
+	"strings"
+)
+
+// unknownConfidence is the AudioWord.Confidence of words without logprob data.
+const unknownConfidence = -1
+
+// WithWordConfidence returns a copy of the response whose Words carry a Confidence computed from
+// Logprobs: the geometric mean of the probabilities of the tokens spelling the word. Tokens are
+// mapped to words by locating every word, in order, in the text the tokens spell. Words that cannot
+// be located, or all of them when the response has no Logprobs, get a Confidence of -1.
+func (r AudioResponse) WithWordConfidence() AudioResponse {
+	if len(r.Words) == 0 {
+		return r
+	}
+
+	// Offsets of every token in the text they spell.
+	var text strings.Builder
+	starts := make([]int, len(r.Logprobs))
+	for i, logprob := range r.Logprobs {
+		starts[i] = text.Len()
+		text.WriteString(logprob.Token)
+	}
+	spelled := text.String()
+
+	words := make([]AudioWord, len(r.Words))
+	pos, token := 0, 0
+	for i, word := range r.Words {
+		words[i] = word
+		words[i].Confidence = unknownConfidence
+		needle := strings.TrimSpace(word.Word)
+		index := strings.Index(spelled[pos:], needle)
+		if needle == "" || index < 0 {
+			continue
+		}
+		start := pos + index
+		pos = start + len(needle)
+
+		// Skip the tokens ending before the word, then average the ones overlapping it.
+		for token < len(starts) && starts[token]+len(r.Logprobs[token].Token) <= start {
+			token++
+		}
+		var sum float64
+		count := 0
+		for k := token; k < len(starts) && starts[k] < pos; k++ {
+			sum += r.Logprobs[k].Logprob
+			count++
+		}
+		if count > 0 {
+			words[i].Confidence = math.Exp(sum / float64(count))
+		}
+	}
+
+	r.Words = words
+	return r
+}
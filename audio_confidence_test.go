@@ -0,0 +1,79 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+const logprobsFixture = `{
+	"text": "Hello wonderful world",
+	"words": [
+		{"word": "Hello", "start": 0, "end": 0.4},
+		{"word": "wonderful", "start": 0.5, "end": 1},
+		{"word": "world", "start": 1.1, "end": 1.5},
+		{"word": "unspoken", "start": 1.6, "end": 1.9}
+	],
+	"logprobs": [
+		{"token": "Hello", "logprob": -0.1},
+		{"token": " wonder", "logprob": -0.5},
+		{"token": "ful", "logprob": -0.3},
+		{"token": " world", "logprob": 0}
+	]
+}`
+
+func TestWithWordConfidence(t *testing.T) {
+	var res openai.AudioResponse
+	checks.NoError(t, json.Unmarshal([]byte(logprobsFixture), &res), "Unmarshal error")
+
+	scored := res.WithWordConfidence()
+	expected := []float64{math.Exp(-0.1), math.Exp(-0.4), 1, -1}
+	for i, word := range scored.Words {
+		if math.Abs(word.Confidence-expected[i]) > 1e-9 {
+			t.Errorf("word %q: expected confidence %v, got %v", word.Word, expected[i], word.Confidence)
+		}
+	}
+	if res.Words[0].Confidence != 0 {
+		t.Error("expected the original words to be left untouched")
+	}
+
+	res.Logprobs = nil
+	for _, word := range res.WithWordConfidence().Words {
+		if word.Confidence != -1 {
+			t.Errorf("expected -1 without logprobs, got %v for %q", word.Confidence, word.Word)
+		}
+	}
+}
+
+func TestAudioIncludeLogprobs(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var include []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		include = r.MultipartForm.Value["include[]"]
+		_, _ = w.Write([]byte(logprobsFixture))
+	})
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Format:   openai.AudioResponseFormatJSON,
+		Include:  []openai.TranscriptionInclude{openai.TranscriptionIncludeLogprobs},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if !reflect.DeepEqual(include, []string{"logprobs"}) {
+		t.Errorf("expected include[]=logprobs, got %q", include)
+	}
+	if len(res.Logprobs) != 4 || res.Logprobs[1].Token != " wonder" {
+		t.Errorf("unexpected logprobs %+v", res.Logprobs)
+	}
+}
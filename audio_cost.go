@@ -0,0 +1,85 @@
+package openai
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+var (
+	ErrDurationProbeUnsupported = errors.New("audio duration can only be probed from WAV headers")
+)
+
+// EstimateTranscriptionCost returns the expected price of transcribing audio of the given duration,
+// billed at pricePerMinute, e.g. 0.006 for whisper-1 in USD. It is a budgeting estimate: the API
+// bills whole seconds, see AudioResponseUsage.
+func EstimateTranscriptionCost(duration time.Duration, pricePerMinute float64) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return duration.Minutes() * pricePerMinute
+}
+
+// ProbeAudioDuration returns the duration of the WAV file at path from its headers, without reading
+// the samples. Other formats would need decoding and fail with ErrDurationProbeUnsupported.
+func ProbeAudioDuration(path string) (time.Duration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("opening audio file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return probeWAVDuration(f, info.Size())
+}
+
+// probeWAVDuration walks the chunk headers of a WAV file of the given size, skipping chunk bodies
+// but the fmt one, and derives the duration from the size of the data chunk.
+func probeWAVDuration(r io.ReadSeeker, size int64) (time.Duration, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil || !isWAV(header) {
+		return 0, ErrDurationProbeUnsupported
+	}
+
+	var byteRate int64
+	for pos := int64(len(header)); ; {
+		chunk := make([]byte, 8)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return 0, fmt.Errorf("%w: missing data chunk", ErrInvalidWAV)
+		}
+		pos += int64(len(chunk))
+		chunkSize := int64(binary.LittleEndian.Uint32(chunk[4:]))
+		switch string(chunk[:4]) {
+		case "fmt ":
+			format := make([]byte, 16)
+			if chunkSize < int64(len(format)) {
+				return 0, fmt.Errorf("%w: fmt chunk too short", ErrInvalidWAV)
+			}
+			if _, err := io.ReadFull(r, format); err != nil {
+				return 0, fmt.Errorf("%w: fmt chunk too short", ErrInvalidWAV)
+			}
+			byteRate = int64(binary.LittleEndian.Uint32(format[8:]))
+			pos += int64(len(format))
+			chunkSize -= int64(len(format))
+		case "data":
+			if byteRate <= 0 {
+				return 0, fmt.Errorf("%w: missing fmt chunk", ErrInvalidWAV)
+			}
+			if remaining := size - pos; chunkSize > remaining {
+				// Streamed WAVs may carry a placeholder size, use what is available.
+				chunkSize = remaining
+			}
+			return time.Duration(chunkSize) * time.Second / time.Duration(byteRate), nil
+		}
+		skip := chunkSize + chunkSize%2
+		if _, err := r.Seek(skip, io.SeekCurrent); err != nil {
+			return 0, err
+		}
+		pos += skip
+	}
+}
@@ -0,0 +1,51 @@
+package openai_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestEstimateTranscriptionCost(t *testing.T) {
+	if cost := openai.EstimateTranscriptionCost(90*time.Second, 0.006); math.Abs(cost-0.009) > 1e-12 {
+		t.Errorf("expected 0.009, got %v", cost)
+	}
+	if cost := openai.EstimateTranscriptionCost(0, 0.006); cost != 0 {
+		t.Errorf("expected no cost without audio, got %v", cost)
+	}
+}
+
+func TestProbeAudioDuration(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		checks.NoError(t, os.WriteFile(path, data, 0o600), "WriteFile error")
+		return path
+	}
+
+	duration, err := openai.ProbeAudioDuration(write("call.wav", testWAV(16000, 2, make([]int16, 3*16000*2))))
+	checks.NoError(t, err, "ProbeAudioDuration error")
+	if duration != 3*time.Second {
+		t.Errorf("expected 3s, got %v", duration)
+	}
+
+	// A LIST chunk before the data chunk is skipped, and a placeholder data size is capped.
+	wav := testWAV(8000, 1, make([]int16, 4000))
+	withList := append(append(append([]byte{}, wav[:36]...), "LIST\x03\x00\x00\x00abc\x00"...), wav[36:]...)
+	copy(withList[len(withList)-8000-4:], []byte{0xff, 0xff, 0xff, 0xff})
+	duration, err = openai.ProbeAudioDuration(write("streamed.wav", withList))
+	checks.NoError(t, err, "ProbeAudioDuration error")
+	if duration != 500*time.Millisecond {
+		t.Errorf("expected 500ms, got %v", duration)
+	}
+
+	_, err = openai.ProbeAudioDuration(write("song.mp3", []byte("ID3\x04 frames")))
+	checks.ErrorIs(t, err, openai.ErrDurationProbeUnsupported, "mp3 should not be probed")
+	_, err = openai.ProbeAudioDuration(write("truncated.wav", wav[:40]))
+	checks.ErrorIs(t, err, openai.ErrInvalidWAV, "truncated WAV should fail")
+}
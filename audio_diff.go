@@ -0,0 +1,114 @@
+package openai
+
+import "strings"
+
+// TranscriptEditType is the kind of a word-level edit between two transcripts.
+type TranscriptEditType string
+
+const (
+	TranscriptEditSubstitution TranscriptEditType = "substitution"
+	TranscriptEditDeletion     TranscriptEditType = "deletion"
+	TranscriptEditInsertion    TranscriptEditType = "insertion"
+)
+
+// TranscriptEdit is a word that differs between the reference and the compared transcript.
+type TranscriptEdit struct {
+	Type TranscriptEditType
+	// Position is the index of the edit in the reference words; insertions take the index of the
+	// reference word they precede.
+	Position  int
+	Reference string // the reference word, empty for insertions
+	Word      string // the compared word, empty for deletions
+}
+
+// TranscriptDiff is the word-level difference between two transcripts.
+type TranscriptDiff struct {
+	Edits []TranscriptEdit
+
+	Substitutions  int
+	Deletions      int
+	Insertions     int
+	ReferenceWords int
+
+	// WER is the word error rate, (Substitutions + Deletions + Insertions) / ReferenceWords. It is 0
+	// when both transcripts are empty and 1 when only the reference is.
+	WER float64
+}
+
+// DiffTranscripts compares the words of b to the reference transcript a and returns the minimal
+// edits turning a into b, ignoring case and punctuation as AlignWords does. Words are taken from
+// Words when present, from the text of the segments otherwise, and from Text without segments.
+func DiffTranscripts(a, b AudioResponse) TranscriptDiff {
+	refWords, hypWords := a.diffWords(), b.diffWords()
+	ref := make([]string, len(refWords))
+	for i, word := range refWords {
+		ref[i] = alignmentKey(word)
+	}
+	hyp := make([]string, len(hypWords))
+	for j, word := range hypWords {
+		hyp[j] = alignmentKey(word)
+	}
+	cost := newEditCosts(ref, hyp)
+
+	diff := TranscriptDiff{ReferenceWords: len(ref)}
+	i, j := 0, 0
+	for i < len(ref) || j < len(hyp) {
+		both := i < len(ref) && j < len(hyp)
+		// On ties, insertions and deletions are preferred over substitutions, as in AlignWords, so
+		// that identical words stay aligned.
+		switch {
+		case both && ref[i] == hyp[j] && cost.at(i, j) == cost.at(i+1, j+1):
+			i++
+			j++
+		case j < len(hyp) && (i == len(ref) || cost.at(i, j) == cost.at(i, j+1)+1):
+			diff.add(TranscriptEdit{Type: TranscriptEditInsertion, Position: i, Word: hypWords[j]})
+			j++
+		case i < len(ref) && (j == len(hyp) || cost.at(i, j) == cost.at(i+1, j)+1):
+			diff.add(TranscriptEdit{Type: TranscriptEditDeletion, Position: i, Reference: refWords[i]})
+			i++
+		default:
+			diff.add(TranscriptEdit{Type: TranscriptEditSubstitution, Position: i, Reference: refWords[i], Word: hypWords[j]})
+			i++
+			j++
+		}
+	}
+
+	switch {
+	case diff.ReferenceWords > 0:
+		diff.WER = float64(len(diff.Edits)) / float64(diff.ReferenceWords)
+	case len(diff.Edits) > 0:
+		diff.WER = 1
+	}
+	return diff
+}
+
+func (d *TranscriptDiff) add(edit TranscriptEdit) {
+	d.Edits = append(d.Edits, edit)
+	switch edit.Type {
+	case TranscriptEditSubstitution:
+		d.Substitutions++
+	case TranscriptEditDeletion:
+		d.Deletions++
+	case TranscriptEditInsertion:
+		d.Insertions++
+	}
+}
+
+// diffWords returns the words compared by DiffTranscripts.
+func (r AudioResponse) diffWords() []string {
+	if len(r.Words) > 0 {
+		words := make([]string, 0, len(r.Words))
+		for _, word := range r.Words {
+			words = append(words, strings.Fields(word.Word)...)
+		}
+		return words
+	}
+	if len(r.Segments) == 0 {
+		return strings.Fields(r.Text)
+	}
+	var words []string
+	for _, segment := range r.Segments {
+		words = append(words, strings.Fields(segment.Text)...)
+	}
+	return words
+}
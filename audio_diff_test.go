@@ -0,0 +1,57 @@
+package openai_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestDiffTranscripts(t *testing.T) {
+	reference := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{Text: " The quick brown fox"},
+			{Text: " jumps over the lazy dog."},
+		},
+	}
+	compared := openai.AudioResponse{
+		Words: []openai.AudioWord{
+			{Word: "the"}, {Word: "quick"}, {Word: "brown"}, {Word: "box"},
+			{Word: "jumps"}, {Word: "over"}, {Word: "a"}, {Word: "the"}, {Word: "dog"},
+		},
+	}
+
+	diff := openai.DiffTranscripts(reference, compared)
+	expected := []openai.TranscriptEdit{
+		{Type: openai.TranscriptEditSubstitution, Position: 3, Reference: "fox", Word: "box"},
+		{Type: openai.TranscriptEditInsertion, Position: 6, Word: "a"},
+		{Type: openai.TranscriptEditDeletion, Position: 7, Reference: "lazy"},
+	}
+	if !reflect.DeepEqual(diff.Edits, expected) {
+		t.Fatalf("unexpected edits:\n%+v\nexpected:\n%+v", diff.Edits, expected)
+	}
+	if diff.Substitutions != 1 || diff.Insertions != 1 || diff.Deletions != 1 || diff.ReferenceWords != 9 {
+		t.Errorf("unexpected counts %+v", diff)
+	}
+	if math.Abs(diff.WER-3.0/9) > 1e-9 {
+		t.Errorf("expected a WER of 3/9, got %v", diff.WER)
+	}
+
+	cases := []struct {
+		a, b string
+		wer  float64
+	}{
+		{"hello world", "Hello, world!", 0},
+		{"hello world", "", 1},
+		{"", "", 0},
+		{"", "hello", 1},
+		{"one two three four", "one three four five six", 0.75},
+	}
+	for _, c := range cases {
+		diff = openai.DiffTranscripts(openai.AudioResponse{Text: c.a}, openai.AudioResponse{Text: c.b})
+		if diff.WER != c.wer {
+			t.Errorf("%q vs %q: expected a WER of %v, got %v (%+v)", c.a, c.b, c.wer, diff.WER, diff.Edits)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// CanonicalJSON returns a deterministic JSON encoding of the response, suitable as a cache key:
+// object keys are sorted and empty values (null, "", 0, false, empty arrays and objects) are
+// omitted. HTTP headers are never part of the output.
+func (r AudioResponse) CanonicalJSON() ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	value, _ = pruneEmptyJSON(value)
+	// encoding/json sorts map keys, which makes the output stable.
+	return json.Marshal(value)
+}
+
+// pruneEmptyJSON removes empty values from a decoded JSON document. It reports whether v itself is empty.
+func pruneEmptyJSON(v any) (any, bool) {
+	switch value := v.(type) {
+	case nil:
+		return nil, true
+	case string:
+		return value, value == ""
+	case bool:
+		return value, !value
+	case json.Number:
+		f, err := value.Float64()
+		return value, err == nil && f == 0
+	case []any:
+		pruned := make([]any, 0, len(value))
+		for _, item := range value {
+			// Keep empty array items so positions are preserved.
+			item, _ = pruneEmptyJSON(item)
+			pruned = append(pruned, item)
+		}
+		return pruned, len(pruned) == 0
+	case map[string]any:
+		for key, item := range value {
+			item, empty := pruneEmptyJSON(item)
+			if empty {
+				delete(value, key)
+				continue
+			}
+			value[key] = item
+		}
+		return value, len(value) == 0
+	default:
+		return value, false
+	}
+}
+
+// csvHeader is the header row written by ToCSV.
+var csvHeader = []string{"id", "start", "end", "speaker", "text"}
+
+// ToCSV writes the segments as CSV, one row per segment after a header row of
+// id,start,end,speaker,text. Times are in seconds with millisecond precision, and text is trimmed
+// unless PreserveWhitespace is set. A response without segments writes only the header.
+func (r AudioResponse) ToCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, segment := range r.Segments {
+		record := []string{
+			strconv.Itoa(segment.ID),
+			strconv.FormatFloat(segment.Start, 'f', 3, 64),
+			strconv.FormatFloat(segment.End, 'f', 3, 64),
+			segment.Speaker,
+			r.normalizeSpace(segment.Text),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ToJSONL writes the segments as JSON Lines: one compact JSON object per segment, each followed by
+// a newline. Segments are written as returned by the API, with optional fields such as speaker
+// and sentiment omitted when unset. A response without segments writes nothing.
+func (r AudioResponse) ToJSONL(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, segment := range r.Segments {
+		if err := encoder.Encode(segment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
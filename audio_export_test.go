@@ -0,0 +1,90 @@
+package openai_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestAudioResponseCanonicalJSON(t *testing.T) {
+	res := openai.AudioResponse{
+		Language: "english",
+		Duration: 1.5,
+		Text:     "Hello.",
+		Segments: []openai.AudioSegment{{ID: 0, Start: 0, End: 1.5, Text: " Hello.", Speaker: "spk_0"}},
+		AudioInfo: &openai.TranscriptionAudioInfo{
+			Duration: 1500,
+		},
+	}
+	res.SetHeader(http.Header{"X-Request-Id": []string{"abc"}})
+
+	first, err := res.CanonicalJSON()
+	checks.NoErrorF(t, err, "CanonicalJSON error")
+	const expected = `{"audio_info":{"duration":1500},"duration":1.5,"language":"english",` +
+		`"segments":[{"end":1.5,"speaker":"spk_0","text":" Hello."}],"text":"Hello."}`
+	if string(first) != expected {
+		t.Fatalf("unexpected canonical JSON:\n%s\nexpected:\n%s", first, expected)
+	}
+
+	for i := 0; i < 20; i++ {
+		again, againErr := res.CanonicalJSON()
+		checks.NoErrorF(t, againErr, "CanonicalJSON error")
+		if !bytes.Equal(first, again) {
+			t.Fatalf("canonical JSON is not stable:\n%s\n%s", first, again)
+		}
+	}
+
+	empty, err := openai.AudioResponse{}.CanonicalJSON()
+	checks.NoErrorF(t, err, "CanonicalJSON error")
+	if string(empty) != "{}" {
+		t.Errorf("expected empty object, got %s", empty)
+	}
+}
+
+func TestAudioResponseToCSV(t *testing.T) {
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{ID: 0, Start: 0, End: 1.25, Text: " Hello, world.", Speaker: "spk_0"},
+			{ID: 1, Start: 1.25, End: 3.5, Text: ` She said "hi"` + "\nand left."},
+		},
+	}
+	var buf bytes.Buffer
+	checks.NoError(t, res.ToCSV(&buf), "ToCSV error")
+	const golden = "id,start,end,speaker,text\n" +
+		"0,0.000,1.250,spk_0,\"Hello, world.\"\n" +
+		"1,1.250,3.500,,\"She said \"\"hi\"\"\nand left.\"\n"
+	if buf.String() != golden {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", buf.String(), golden)
+	}
+
+	buf.Reset()
+	checks.NoError(t, openai.AudioResponse{}.ToCSV(&buf), "ToCSV error")
+	if buf.String() != "id,start,end,speaker,text\n" {
+		t.Errorf("expected only the header, got %q", buf.String())
+	}
+}
+
+func TestAudioResponseToJSONL(t *testing.T) {
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{ID: 0, Start: 0, End: 1.25, Text: " Hello & welcome."},
+			{ID: 1, Start: 1.25, End: 3.5, Text: " Thanks!", Speaker: "spk_1", Sentiment: "happy"},
+		},
+	}
+	var buf bytes.Buffer
+	checks.NoError(t, res.ToJSONL(&buf), "ToJSONL error")
+	const golden = `{"id":0,"start":0,"end":1.25,"text":" Hello & welcome."}` + "\n" +
+		`{"id":1,"start":1.25,"end":3.5,"text":" Thanks!","speaker":"spk_1","sentiment":"happy"}` + "\n"
+	if buf.String() != golden {
+		t.Errorf("unexpected JSONL:\n%s\nexpected:\n%s", buf.String(), golden)
+	}
+
+	buf.Reset()
+	checks.NoError(t, openai.AudioResponse{}.ToJSONL(&buf), "ToJSONL error")
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,183 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	defaultFallbackTemperatureIncrement = 0.2
+	defaultFallbackNoSpeechThreshold    = 0.6
+	maxTranscriptionTemperature         = 1
+)
+
+// NoSpeechFallback configures the single retry CreateTranscription makes when the first result
+// is empty or most likely not speech, which a different temperature often fixes.
+type NoSpeechFallback struct {
+	// TemperatureIncrement is added to the request Temperature for the retry, capped at 1.
+	// Defaults to 0.2.
+	TemperatureIncrement float32
+	// NoSpeechThreshold is the average segment NoSpeechProb above which a result is retried.
+	// Defaults to 0.6; it only applies to verbose_json responses, which carry segments.
+	NoSpeechThreshold float64
+}
+
+func (f NoSpeechFallback) needsRetry(response AudioResponse) bool {
+	if strings.TrimSpace(response.Text) == "" {
+		return true
+	}
+	threshold := f.NoSpeechThreshold
+	if threshold <= 0 {
+		threshold = defaultFallbackNoSpeechThreshold
+	}
+	return len(response.Segments) > 0 && response.averageNoSpeechProb() > threshold
+}
+
+func (f NoSpeechFallback) retryTemperature(temperature float32) float32 {
+	increment := f.TemperatureIncrement
+	if increment <= 0 {
+		increment = defaultFallbackTemperatureIncrement
+	}
+	if temperature += increment; temperature > maxTranscriptionTemperature {
+		temperature = maxTranscriptionTemperature
+	}
+	return temperature
+}
+
+// averageNoSpeechProb returns the mean NoSpeechProb of the segments, 0 without segments.
+func (r AudioResponse) averageNoSpeechProb() float64 {
+	if len(r.Segments) == 0 {
+		return 0
+	}
+	var total float64
+	for _, segment := range r.Segments {
+		total += segment.NoSpeechProb
+	}
+	return total / float64(len(r.Segments))
+}
+
+// betterTranscription returns the response most likely to hold actual speech: a non-empty text
+// wins, then the lowest average NoSpeechProb. Ties keep first.
+func betterTranscription(first, second AudioResponse) AudioResponse {
+	firstEmpty, secondEmpty := strings.TrimSpace(first.Text) == "", strings.TrimSpace(second.Text) == ""
+	if firstEmpty != secondEmpty {
+		if firstEmpty {
+			return second
+		}
+		return first
+	}
+	if second.averageNoSpeechProb() < first.averageNoSpeechProb() {
+		return second
+	}
+	return first
+}
+
+// transcribeWithFallback retries an empty or no-speech transcription once, as configured by
+// request.NoSpeechFallback, and returns the better of both results. When the retry fails the
+// first result is returned.
+func (c *Client) transcribeWithFallback(ctx context.Context, request AudioRequest) (AudioResponse, error) {
+	fallback := *request.NoSpeechFallback
+	request.NoSpeechFallback = nil
+
+	// The input must be uploaded twice.
+	rewind, err := replayableAudio(&request)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+
+	first, err := c.callAudioAPI(ctx, request, "transcriptions")
+	if err != nil || !fallback.needsRetry(first) {
+		return first, err
+	}
+
+	request.Temperature = fallback.retryTemperature(request.Temperature)
+	if rewind() == nil {
+		if second, retryErr := c.callAudioAPI(ctx, request, "transcriptions"); retryErr == nil {
+			return betterTranscription(first, second), nil
+		}
+	}
+	return first, nil
+}
+
+// replayableAudio prepares the input of request to be uploaded several times and returns the
+// function rewinding it before each new upload. Readers that cannot be rewound are read into memory.
+func replayableAudio(request *AudioRequest) (func() error, error) {
+	switch reader := request.Reader.(type) {
+	case nil:
+		return func() error { return nil }, nil
+	case io.Seeker:
+		start, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("reading audio position: %w", err)
+		}
+		return func() error {
+			_, err := reader.Seek(start, io.SeekStart)
+			return err
+		}, nil
+	default:
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("reading audio: %w", err)
+		}
+		request.Reader = bytes.NewReader(data)
+		return func() error {
+			request.Reader = bytes.NewReader(data)
+			return nil
+		}, nil
+	}
+}
+
+// Quality thresholds of the temperature schedule, the defaults of the reference Whisper implementation.
+const (
+	scheduleCompressionRatioThreshold = 2.4
+	scheduleLogprobThreshold          = -1
+)
+
+// passesQualityChecks reports whether a transcription is good enough to stop the temperature
+// schedule: a highly compressible text hints at repetitions, a low average log probability at
+// guesswork. Responses without segments, i.e. other formats than verbose_json, always pass.
+func (r AudioResponse) passesQualityChecks() bool {
+	if len(r.Segments) == 0 {
+		return true
+	}
+	var compression, logprob float64
+	for _, segment := range r.Segments {
+		compression += segment.CompressionRatio
+		logprob += segment.AvgLogprob
+	}
+	count := float64(len(r.Segments))
+	return compression/count <= scheduleCompressionRatioThreshold && logprob/count >= scheduleLogprobThreshold
+}
+
+// transcribeWithSchedule transcribes with each temperature of request.TemperatureSchedule in turn,
+// until a result passes the quality checks. The result of the last temperature is returned when
+// none does.
+func (c *Client) transcribeWithSchedule(ctx context.Context, request AudioRequest) (AudioResponse, error) {
+	schedule := request.TemperatureSchedule
+	request.TemperatureSchedule = nil
+	request.NoSpeechFallback = nil
+	rewind, err := replayableAudio(&request)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+
+	var response AudioResponse
+	for i, temperature := range schedule {
+		if i > 0 {
+			if err = rewind(); err != nil {
+				return AudioResponse{}, fmt.Errorf("rewinding audio: %w", err)
+			}
+		}
+		request.Temperature = temperature
+		if response, err = c.callAudioAPI(ctx, request, "transcriptions"); err != nil {
+			return AudioResponse{}, err
+		}
+		if response.passesQualityChecks() {
+			break
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,131 @@
+package openai_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestNoSpeechFallback(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var temperatures, bodies []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, _ := r.FormFile("file")
+		data, _ := io.ReadAll(file)
+		bodies = append(bodies, string(data))
+		temperatures = append(temperatures, r.FormValue("temperature"))
+		if len(temperatures) == 1 {
+			_, _ = w.Write([]byte(`{"text":""}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"text":"hello world"}`))
+	})
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:            openai.Whisper1,
+		FilePath:         "audio.mp3",
+		Reader:           io.MultiReader(strings.NewReader("some audio")),
+		Temperature:      0.1,
+		NoSpeechFallback: &openai.NoSpeechFallback{TemperatureIncrement: 0.3},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "hello world" {
+		t.Fatalf("expected the retried result, got %q", res.Text)
+	}
+	if len(temperatures) != 2 || temperatures[0] != "0.10" || temperatures[1] != "0.40" {
+		t.Fatalf("unexpected temperatures: %q", temperatures)
+	}
+	if bodies[0] != "some audio" || bodies[1] != "some audio" {
+		t.Fatalf("audio not uploaded twice: %q", bodies)
+	}
+}
+
+func TestNoSpeechFallbackRetriesOnce(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		probability := "0.9"
+		if calls == 1 {
+			probability = "0.95"
+		}
+		_, _ = w.Write([]byte(`{"text":"uh","segments":[{"id":0,"start":0,"end":1,"text":"uh","no_speech_prob":` +
+			probability + `}]}`))
+	})
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:            openai.Whisper1,
+		FilePath:         "audio.mp3",
+		Reader:           strings.NewReader("some audio"),
+		Format:           openai.AudioResponseFormatVerboseJSON,
+		NoSpeechFallback: &openai.NoSpeechFallback{},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if calls != 2 {
+		t.Fatalf("expected exactly one retry, got %d calls", calls)
+	}
+	if res.Segments[0].NoSpeechProb != 0.9 {
+		t.Errorf("expected the result with the lowest no speech probability, got %v", res.Segments[0].NoSpeechProb)
+	}
+}
+
+func TestTemperatureSchedule(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	// The first result repeats itself, the second one is guesswork, the third one is fine.
+	responses := []string{
+		`{"text":"la la la","segments":[{"text":"la la la","compression_ratio":3.1,"avg_logprob":-0.2}]}`,
+		`{"text":"maybe","segments":[{"text":"maybe","compression_ratio":1.2,"avg_logprob":-1.4}]}`,
+		`{"text":"hello world","segments":[{"text":"hello world","compression_ratio":1.1,"avg_logprob":-0.3}]}`,
+	}
+	var temperatures, bodies []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, _ := r.FormFile("file")
+		data, _ := io.ReadAll(file)
+		bodies = append(bodies, string(data))
+		temperatures = append(temperatures, r.FormValue("temperature"))
+		_, _ = w.Write([]byte(responses[len(temperatures)-1]))
+	})
+
+	request := openai.AudioRequest{
+		Model:               openai.Whisper1,
+		FilePath:            "audio.mp3",
+		Reader:              io.MultiReader(strings.NewReader("some audio")),
+		Format:              openai.AudioResponseFormatVerboseJSON,
+		Temperature:         0.9,
+		TemperatureSchedule: []float32{0, 0.2, 0.4, 0.6},
+	}
+	res, err := client.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "hello world" {
+		t.Fatalf("expected the first result passing the checks, got %q", res.Text)
+	}
+	if strings.Join(temperatures, ",") != ",0.20,0.40" { // a zero temperature is the API default and not sent
+		t.Fatalf("unexpected temperatures: %q", temperatures)
+	}
+	for _, body := range bodies {
+		if body != "some audio" {
+			t.Fatalf("audio not replayed: %q", bodies)
+		}
+	}
+
+	// When no temperature passes, the last result is returned.
+	temperatures = nil
+	request.Reader = strings.NewReader("some audio")
+	request.TemperatureSchedule = []float32{0, 0.2}
+	res, err = client.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "maybe" || len(temperatures) != 2 {
+		t.Fatalf("expected the last result after %q, got %q", temperatures, res.Text)
+	}
+}
@@ -0,0 +1,101 @@
+package openai
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// BuildTranscriptionForm returns the multipart fields CreateTranscription would send for request,
+// after defaults are applied, e.g. for audit logging. The audio file is not part of the result and
+// the input is neither opened nor read. Fields with several values, such as
+// timestamp_granularities[], keep them in order.
+func (c *Client) BuildTranscriptionForm(request AudioRequest) (map[string][]string, error) {
+	request, err := request.withTimestampFormat()
+	if err != nil {
+		return nil, err
+	}
+	if request, err = c.prepareAudioRequest(request); err != nil {
+		return nil, err
+	}
+	// Of the input transformations, only channel selection changes form fields.
+	if request.InputChannelSelect != nil {
+		if request, err = selectChannelFields(request); err != nil {
+			return nil, err
+		}
+	}
+	if err = request.validateForm(); err != nil {
+		return nil, err
+	}
+	fields := formFieldRecorder{}
+	if err = writeAudioFields(request, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// prepareAudioRequest applies the client-level audio options of the config to the request.
+func (c *Client) prepareAudioRequest(request AudioRequest) (AudioRequest, error) {
+	if err := c.checkKnownModel(request.Model); err != nil {
+		return AudioRequest{}, err
+	}
+	if c.config.StrictAudioInput && (request.Reader != nil || request.DataURI != "") && request.FilePath != "" {
+		if _, err := os.Stat(request.FilePath); err == nil {
+			return AudioRequest{}, fmt.Errorf("%w: %s", ErrAmbiguousAudioInput, request.FilePath)
+		}
+	}
+	if resolve := c.config.AudioFormatResolver; resolve != nil && request.DataURI == "" {
+		if mimeType, ok := resolve(request.uploadFilename()); ok {
+			request.contentType = mimeType
+		}
+	}
+	request.Prompt = c.config.DefaultPromptPrefix + request.Prompt + c.config.DefaultPromptSuffix
+	request.promptField = c.config.PromptFieldName
+	return request, nil
+}
+
+// transformAudioInput applies the client-side edits of the input requested by StartOffset and
+// EndOffset, InputChannelSelect and NormalizeAudio, in this order.
+func transformAudioInput(request AudioRequest) (AudioRequest, error) {
+	var err error
+	if request.hasOffsets() {
+		if request, err = trimAudioRequest(request); err != nil {
+			return AudioRequest{}, err
+		}
+	}
+	if request.InputChannelSelect != nil {
+		if request, err = selectChannelRequest(request); err != nil {
+			return AudioRequest{}, err
+		}
+	}
+	if request.NormalizeAudio != "" {
+		if request, err = normalizeAudioRequest(request); err != nil {
+			return AudioRequest{}, err
+		}
+	}
+	return request, nil
+}
+
+// formFieldRecorder is a FormBuilder that records the written fields and ignores files.
+type formFieldRecorder map[string][]string
+
+func (f formFieldRecorder) CreateFormFile(string, *os.File) error {
+	return nil
+}
+
+func (f formFieldRecorder) CreateFormFileReader(string, io.Reader, string) error {
+	return nil
+}
+
+func (f formFieldRecorder) WriteField(fieldname, value string) error {
+	f[fieldname] = append(f[fieldname], value)
+	return nil
+}
+
+func (f formFieldRecorder) Close() error {
+	return nil
+}
+
+func (f formFieldRecorder) FormDataContentType() string {
+	return ""
+}
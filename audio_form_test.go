@@ -0,0 +1,272 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestBuildTranscriptionForm(t *testing.T) {
+	client := openai.NewClient("token")
+
+	fields, err := client.BuildTranscriptionForm(openai.AudioRequest{
+		Model:           openai.Whisper1,
+		FilePath:        "does-not-exist.pcm",
+		Language:        "de",
+		Temperature:     0.3,
+		Format:          openai.AudioResponseFormatVerboseJSON,
+		InputSampleRate: 16000,
+		InputChannels:   1,
+		TimestampGranularities: []openai.TranscriptionTimestampGranularity{
+			openai.TranscriptionTimestampGranularityWord,
+			openai.TranscriptionTimestampGranularitySegment,
+		},
+	})
+	checks.NoError(t, err, "BuildTranscriptionForm error")
+	expected := map[string][]string{
+		"model":                     {"whisper-1"},
+		"language":                  {"de"},
+		"temperature":               {"0.30"},
+		"response_format":           {"verbose_json"},
+		"sample_rate":               {"16000"},
+		"channels":                  {"1"},
+		"encoding":                  {"pcm_s16le"},
+		"timestamp_granularities[]": {"word", "segment"},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected fields:\n%v\nexpected:\n%v", fields, expected)
+	}
+
+	_, err = client.BuildTranscriptionForm(openai.AudioRequest{Model: openai.Whisper1, FilePath: "audio.raw"})
+	if !errors.Is(err, openai.ErrPCMFormatRequired) {
+		t.Errorf("expected ErrPCMFormatRequired, got %v", err)
+	}
+}
+
+func TestBuildTranscriptionFormChannelSelect(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var sent map[string][]string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		sent = r.MultipartForm.Value
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	right := 1
+	request := openai.AudioRequest{
+		Model:              openai.Whisper1,
+		FilePath:           "call.pcm",
+		InputSampleRate:    8000,
+		InputChannels:      2,
+		InputChannelSelect: &right,
+	}
+
+	fields, err := client.BuildTranscriptionForm(request)
+	checks.NoError(t, err, "BuildTranscriptionForm error")
+	request.Reader = strings.NewReader("\x01\x00\xff\xff\x02\x00\xfe\xff")
+	_, err = client.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	if !reflect.DeepEqual(fields, sent) || fields["channels"][0] != "1" {
+		t.Errorf("expected the built form to match the sent one, built:\n%v\nsent:\n%v", fields, sent)
+	}
+
+	request.InputChannels = 0
+	_, err = client.BuildTranscriptionForm(request)
+	checks.ErrorIs(t, err, openai.ErrPCMFormatRequired, "expected the missing channel count to be reported")
+}
+
+func TestAudioModelParams(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var fields map[string][]string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		fields = r.MultipartForm.Value
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:       openai.Whisper1,
+		FilePath:    "audio.mp3",
+		Reader:      strings.NewReader("audio"),
+		ModelParams: map[string]string{"beam_size": "5", "best_of": "3"},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if !reflect.DeepEqual(fields["beam_size"], []string{"5"}) || !reflect.DeepEqual(fields["best_of"], []string{"3"}) {
+		t.Errorf("model parameters were not encoded: %v", fields)
+	}
+
+	for _, key := range []string{"temperature", "model", "timestamp_granularities[]"} {
+		_, err = client.BuildTranscriptionForm(openai.AudioRequest{
+			Model:       openai.Whisper1,
+			FilePath:    "audio.mp3",
+			ModelParams: map[string]string{key: "1"},
+		})
+		if !errors.Is(err, openai.ErrModelParamConflict) {
+			t.Errorf("%s: expected ErrModelParamConflict, got %v", key, err)
+		}
+	}
+}
+
+func TestAudioDefaultPrompt(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.DefaultPromptPrefix = "Glossary: Kubernetes, etcd. "
+	config.DefaultPromptSuffix = " Speakers: Ana, Bo."
+	client := openai.NewClientWithConfig(config)
+
+	var prompt string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		prompt = r.FormValue("prompt")
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		Reader:   strings.NewReader("audio"),
+		FilePath: "audio.mp3",
+		Prompt:   "Standup meeting.",
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if expected := "Glossary: Kubernetes, etcd. Standup meeting. Speakers: Ana, Bo."; prompt != expected {
+		t.Errorf("expected prompt %q, got %q", expected, prompt)
+	}
+
+	config.DefaultPromptSuffix = ""
+	fields, err := openai.NewClientWithConfig(config).BuildTranscriptionForm(openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+	})
+	checks.NoError(t, err, "BuildTranscriptionForm error")
+	if got := fields["prompt"]; len(got) != 1 || got[0] != "Glossary: Kubernetes, etcd. " {
+		t.Errorf("expected the prefix alone as the prompt, got %q", got)
+	}
+}
+
+func TestAudioReaderAndFilePath(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+
+	var uploaded string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, _ := r.FormFile("file")
+		data, _ := io.ReadAll(file)
+		uploaded = string(data)
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+
+	path := filepath.Join(t.TempDir(), "on-disk.mp3")
+	checks.NoError(t, os.WriteFile(path, []byte("file audio"), 0o600), "WriteFile error")
+	request := openai.AudioRequest{Model: openai.Whisper1, FilePath: path, Reader: strings.NewReader("reader audio")}
+
+	// By default the Reader wins and FilePath only names it.
+	_, err := openai.NewClientWithConfig(config).CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "CreateTranscription error")
+	if uploaded != "reader audio" {
+		t.Errorf("expected the Reader to be uploaded, got %q", uploaded)
+	}
+
+	config.StrictAudioInput = true
+	strict := openai.NewClientWithConfig(config)
+	_, err = strict.CreateTranscription(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrAmbiguousAudioInput, "expected the strict mode to reject both inputs")
+
+	request.Reader = strings.NewReader("reader audio")
+	request.FilePath = "name-only.mp3"
+	_, err = strict.CreateTranscription(context.Background(), request)
+	checks.NoError(t, err, "a FilePath naming no file is a plain filename")
+}
+
+func TestAudioPromptFieldName(t *testing.T) {
+	config := openai.DefaultConfig("token")
+	config.PromptFieldName = "initial_prompt"
+	client := openai.NewClientWithConfig(config)
+
+	request := openai.AudioRequest{Model: openai.Whisper1, FilePath: "audio.mp3", Prompt: "Standup meeting."}
+	fields, err := client.BuildTranscriptionForm(request)
+	checks.NoError(t, err, "BuildTranscriptionForm error")
+	if got := fields["initial_prompt"]; !reflect.DeepEqual(got, []string{"Standup meeting."}) {
+		t.Errorf("expected the prompt in initial_prompt, got %q", got)
+	}
+	if _, ok := fields["prompt"]; ok {
+		t.Errorf("expected no prompt field, got %q", fields["prompt"])
+	}
+
+	request.ModelParams = map[string]string{"initial_prompt": "other"}
+	_, err = client.BuildTranscriptionForm(request)
+	checks.ErrorIs(t, err, openai.ErrModelParamConflict, "expected the prompt field to be protected")
+
+	fields, err = openai.NewClient("token").BuildTranscriptionForm(openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Prompt:   "Standup meeting.",
+	})
+	checks.NoError(t, err, "BuildTranscriptionForm error")
+	if got := fields["prompt"]; !reflect.DeepEqual(got, []string{"Standup meeting."}) {
+		t.Errorf("expected the prompt field by default, got %q", got)
+	}
+}
+
+// recordingFormBuilder delegates to the default FormBuilder and records the written fields.
+type recordingFormBuilder struct {
+	openai.FormBuilder
+	fields map[string]string
+}
+
+func (b *recordingFormBuilder) WriteField(fieldname, value string) error {
+	b.fields[fieldname] = value
+	return b.FormBuilder.WriteField(fieldname, value)
+}
+
+func TestFormBuilderFactory(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := r.FormFile("file"); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	builder := &recordingFormBuilder{fields: make(map[string]string)}
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.FormBuilderFactory = func(body io.Writer) openai.FormBuilder {
+		builder.FormBuilder = openai.NewFormBuilder(body)
+		return builder
+	}
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Language: "en",
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if builder.fields["model"] != openai.Whisper1 || builder.fields["language"] != "en" {
+		t.Errorf("expected the fields to go through the custom FormBuilder, got %v", builder.fields)
+	}
+}
@@ -0,0 +1,60 @@
+package openai
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	utils "github.com/sashabaranov/go-openai/internal"
+)
+
+const gzipExtension = ".gz"
+
+// isCompressed reports whether the file or reader input is gzip-compressed.
+func (r AudioRequest) isCompressed() bool {
+	return r.Compressed || strings.EqualFold(filepath.Ext(r.FilePath), gzipExtension)
+}
+
+// uploadFilename returns the name of the uploaded audio, i.e. FilePath without the suffix of
+// compressed input.
+func (r AudioRequest) uploadFilename() string {
+	if ext := filepath.Ext(r.FilePath); strings.EqualFold(ext, gzipExtension) {
+		return strings.TrimSuffix(r.FilePath, ext)
+	}
+	return r.FilePath
+}
+
+// createCompressedFileField creates the "file" form field from gzip-compressed input, decompressing
+// it while it is copied into the form.
+func createCompressedFileField(request AudioRequest, b utils.FormBuilder) error {
+	reader := request.Reader
+	if reader == nil {
+		f, err := os.Open(request.FilePath)
+		if err != nil {
+			return fmt.Errorf("opening audio file: %w", err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("decompressing audio: %w", err)
+	}
+	defer gz.Close()
+
+	var audio io.Reader = gz
+	if request.ValidateAudio && !request.isRawPCM() {
+		if audio, err = ValidateAudioReader(audio); err != nil {
+			return err
+		}
+	}
+	err = b.CreateFormFileReader("file", request.typed(audio), request.uploadFilename())
+	if err != nil {
+		return fmt.Errorf("creating form using compressed audio: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package openai_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(data)
+	checks.NoError(t, err, "gzip Write error")
+	checks.NoError(t, gz.Close(), "gzip Close error")
+	return buf.Bytes()
+}
+
+func TestAudioCompressedInput(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	audio := silentWAV(time.Second)
+	var uploads []string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if !bytes.Equal(data, audio) {
+			http.Error(w, "audio was not decompressed", http.StatusBadRequest)
+			return
+		}
+		uploads = append(uploads, header.Filename)
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+
+	path := filepath.Join(t.TempDir(), "call.wav.gz")
+	checks.NoError(t, os.WriteFile(path, gzipped(t, audio), 0o600), "WriteFile error")
+
+	requests := []openai.AudioRequest{
+		{Model: openai.Whisper1, FilePath: path, ValidateAudio: true},
+		{Model: openai.Whisper1, FilePath: "memo.wav", Reader: bytes.NewReader(gzipped(t, audio)), Compressed: true},
+		{Model: openai.Whisper1, FilePath: path, EndOffset: 2 * time.Second},
+	}
+	for _, request := range requests {
+		_, err := client.CreateTranscription(context.Background(), request)
+		checks.NoError(t, err, "CreateTranscription error")
+	}
+	expected := []string{"call.wav", "memo.wav", "call.wav"}
+	if len(uploads) != len(expected) {
+		t.Fatalf("expected %d uploads, got %v", len(expected), uploads)
+	}
+	for i, name := range expected {
+		if uploads[i] != name {
+			t.Errorf("upload %d: expected filename %q, got %q", i, name, uploads[i])
+		}
+	}
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "broken.wav.gz",
+		Reader:   bytes.NewReader(audio),
+	})
+	checks.HasError(t, err, "uncompressed input named .gz should fail")
+}
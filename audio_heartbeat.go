@@ -0,0 +1,56 @@
+package openai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultHeartbeatInterval = 5 * time.Second
+
+// startHeartbeat calls fn with the elapsed time every interval until ctx is done or the returned
+// stop function is called. Once stop returns, fn is not called anymore.
+func startHeartbeat(ctx context.Context, interval time.Duration, fn func(elapsed time.Duration)) (stop func()) {
+	if fn == nil {
+		return func() {}
+	}
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		start := time.Now()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Prefer stopping when both are ready.
+				select {
+				case <-done:
+					return
+				case <-ctx.Done():
+					return
+				default:
+				}
+				fn(time.Since(start))
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
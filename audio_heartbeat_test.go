@@ -0,0 +1,83 @@
+package openai_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestAudioHeartbeat(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+
+	var beats int32
+	var lastElapsed atomic.Value
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:             openai.Whisper1,
+		FilePath:          "audio.mp3",
+		Reader:            strings.NewReader("audio"),
+		HeartbeatInterval: 10 * time.Millisecond,
+		Heartbeat: func(elapsed time.Duration) {
+			atomic.AddInt32(&beats, 1)
+			lastElapsed.Store(elapsed)
+		},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+
+	fired := atomic.LoadInt32(&beats)
+	if fired < 2 {
+		t.Fatalf("expected heartbeats during the slow call, got %d", fired)
+	}
+	if elapsed, _ := lastElapsed.Load().(time.Duration); elapsed <= 0 {
+		t.Errorf("expected a positive elapsed time, got %v", elapsed)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if after := atomic.LoadInt32(&beats); after != fired {
+		t.Fatalf("heartbeat kept firing after the response: %d then %d", fired, after)
+	}
+}
+
+func TestAudioHeartbeatStopsOnCancel(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	release := make(chan struct{})
+	defer close(release)
+	server.RegisterHandler("/v1/audio/transcriptions", func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var beats int32
+	_, err := client.CreateTranscription(ctx, openai.AudioRequest{
+		Model:             openai.Whisper1,
+		FilePath:          "audio.mp3",
+		Reader:            strings.NewReader("audio"),
+		HeartbeatInterval: 10 * time.Millisecond,
+		Heartbeat: func(time.Duration) {
+			atomic.AddInt32(&beats, 1)
+		},
+	})
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "expected the call to time out")
+
+	fired := atomic.LoadInt32(&beats)
+	time.Sleep(50 * time.Millisecond)
+	if after := atomic.LoadInt32(&beats); after != fired {
+		t.Fatalf("heartbeat kept firing after cancellation: %d then %d", fired, after)
+	}
+}
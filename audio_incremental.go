@@ -0,0 +1,104 @@
+package openai
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// IncrementalTranscriptFile keeps a JSON Lines transcript file, as written by ToJSONL, up to date
+// while a long transcription runs. Segments are collected in memory and, at most every interval,
+// the whole transcript is written to a temporary file in the same directory that is atomically
+// renamed over the target. A crash therefore leaves either the previous or the new version of the
+// file, both valid partial transcripts. It is safe for concurrent use.
+type IncrementalTranscriptFile struct {
+	path     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	segments  []AudioSegment
+	dirty     bool
+	committed time.Time
+}
+
+// NewIncrementalTranscriptFile returns an IncrementalTranscriptFile writing to path. With a zero
+// interval the file is rewritten on every Add.
+func NewIncrementalTranscriptFile(path string, interval time.Duration) *IncrementalTranscriptFile {
+	return &IncrementalTranscriptFile{path: path, interval: interval}
+}
+
+// Add records finalized segments and rewrites the file when interval has elapsed since the last
+// write. Segments are kept ordered by start time, whatever the order they are added in, and
+// renumbered so that IDs are unique across the file, e.g. for chunk responses that each number
+// their segments from 0.
+func (f *IncrementalTranscriptFile) Add(segments ...AudioSegment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.segments = append(f.segments, segments...)
+	sort.SliceStable(f.segments, func(i, j int) bool { return f.segments[i].Start < f.segments[j].Start })
+	for i := range f.segments {
+		f.segments[i].ID = i
+	}
+	f.dirty = true
+	if time.Since(f.committed) < f.interval {
+		return nil
+	}
+	return f.commit()
+}
+
+// Close writes the complete transcript. The file is written even when no segment was added.
+func (f *IncrementalTranscriptFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.dirty && !f.committed.IsZero() {
+		return nil
+	}
+	return f.commit()
+}
+
+// commit atomically replaces the file with the current transcript. f.mu must be held.
+func (f *IncrementalTranscriptFile) commit() error {
+	var buf bytes.Buffer
+	if err := (AudioResponse{Segments: f.segments}).ToJSONL(&buf); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), "."+filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating transcript file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	_, err = tmp.Write(buf.Bytes())
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path)
+	}
+	if err != nil {
+		return fmt.Errorf("writing transcript file: %w", err)
+	}
+	f.dirty = false
+	f.committed = time.Now()
+	return nil
+}
+
+// StreamToIncrementalFile adds every final segment received from stream to file, and closes file
+// once the stream is done so that it holds the complete transcript. When the stream fails the file
+// keeps the segments received so far and the error is returned. The caller still owns and must
+// Close the stream.
+func StreamToIncrementalFile(stream *TranscriptionStream, file *IncrementalTranscriptFile) error {
+	err := forEachFinalSegment(stream, func(segment AudioSegment) error {
+		return file.Add(segment)
+	})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestIncrementalTranscriptFileChunked(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_, header, _ := r.FormFile("file")
+		if header.Filename == "long-2.wav" {
+			// The job crashes while the third chunk is transcribed.
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"message":"boom"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"text":"chunk","segments":[{"id":0,"start":0,"end":1,"text":" chunk"}]}`))
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	file := openai.NewIncrementalTranscriptFile(path, 0)
+	_, err := client.CreateTranscriptionChunked(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "long.wav",
+		Reader:   bytes.NewReader(silentWAV(4 * time.Second)),
+	}, openai.TranscriptionChunkOptions{
+		ChunkDuration: time.Second,
+		OnChunk: func(_ int, chunk openai.AudioResponse) {
+			checks.NoError(t, file.Add(chunk.Segments...), "Add error")
+		},
+	})
+	checks.HasError(t, err, "expected the third chunk to fail")
+
+	// The file holds the transcript of the chunks completed before the failure, with unique IDs
+	// although every chunk numbers its segments from 0.
+	data, err := os.ReadFile(path)
+	checks.NoError(t, err, "ReadFile error")
+	const expected = `{"id":0,"start":0,"end":1,"text":" chunk"}` + "\n" +
+		`{"id":1,"start":1,"end":2,"text":" chunk"}` + "\n"
+	if string(data) != expected {
+		t.Errorf("unexpected partial transcript:\n%s", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary file to be left behind, got %d entries", len(entries))
+	}
+}
+
+func TestStreamToIncrementalFile(t *testing.T) {
+	stream := newScriptedTranscriptionStream(t, scriptedSegmentEvents...)
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	// With a long interval only the final Close writes the file.
+	file := openai.NewIncrementalTranscriptFile(path, time.Hour)
+	checks.NoError(t, file.Add(), "Add error")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the first Add to write the file: %v", err)
+	}
+
+	checks.NoError(t, openai.StreamToIncrementalFile(stream, file), "StreamToIncrementalFile error")
+	data, err := os.ReadFile(path)
+	checks.NoError(t, err, "ReadFile error")
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "General Kenobi!") {
+		t.Errorf("expected the complete transcript, got:\n%s", data)
+	}
+}
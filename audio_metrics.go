@@ -0,0 +1,74 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AudioCallMetrics describes a single audio API exchange.
+type AudioCallMetrics struct {
+	Endpoint      string        // e.g. "/audio/transcriptions"
+	BytesSent     int64         // size of the request body, -1 when unknown
+	BytesReceived int64         // response body bytes actually read by the client
+	StatusCode    int           // 0 when no response was received
+	Duration      time.Duration // from sending the request until the response body is closed
+	Err           error         // transport error, if any
+}
+
+// meteredHTTPDoer wraps an HTTPDoer and reports AudioCallMetrics once the response body is closed.
+// Bytes are counted as they are read so the body is never read twice.
+type meteredHTTPDoer struct {
+	doer     HTTPDoer
+	endpoint string
+	report   func(AudioCallMetrics)
+}
+
+func (d *meteredHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	metrics := AudioCallMetrics{
+		Endpoint:  d.endpoint,
+		BytesSent: req.ContentLength,
+	}
+	start := time.Now()
+	resp, err := d.doer.Do(req)
+	if err != nil {
+		metrics.Duration = time.Since(start)
+		metrics.Err = err
+		d.report(metrics)
+		return resp, err
+	}
+
+	metrics.StatusCode = resp.StatusCode
+	resp.Body = &meteredBody{
+		ReadCloser: resp.Body,
+		metrics:    metrics,
+		start:      start,
+		report:     d.report,
+	}
+	return resp, nil
+}
+
+type meteredBody struct {
+	io.ReadCloser
+
+	metrics AudioCallMetrics
+	start   time.Time
+	report  func(AudioCallMetrics)
+	once    sync.Once
+}
+
+func (b *meteredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.metrics.BytesReceived += int64(n)
+	return n, err
+}
+
+func (b *meteredBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.metrics.Duration = time.Since(b.start)
+		b.report(b.metrics)
+	})
+	return err
+}
@@ -0,0 +1,115 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func setupAudioMetricsTestServer(
+	recorded *[]openai.AudioCallMetrics,
+) (client *openai.Client, server *test.ServerTest, teardown func()) {
+	server = test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	teardown = ts.Close
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.AudioMetricsFunc = func(m openai.AudioCallMetrics) {
+		*recorded = append(*recorded, m)
+	}
+	client = openai.NewClientWithConfig(config)
+	return
+}
+
+func TestAudioMetrics(t *testing.T) {
+	var recorded []openai.AudioCallMetrics
+	client, server, teardown := setupAudioMetricsTestServer(&recorded)
+	defer teardown()
+
+	const transcription = `{"text":"hello world"}`
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte(transcription))
+	})
+	const speech = "fake mp3 bytes"
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(speech))
+	})
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("some audio"),
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if len(recorded) != 1 {
+		t.Fatalf("expected 1 metrics record after transcription, got %d", len(recorded))
+	}
+	m := recorded[0]
+	if m.Endpoint != "/audio/transcriptions" || m.StatusCode != http.StatusOK {
+		t.Errorf("unexpected metrics: %+v", m)
+	}
+	if m.BytesSent <= int64(len("some audio")) {
+		t.Errorf("expected BytesSent to include the multipart body, got %d", m.BytesSent)
+	}
+	if m.BytesReceived != int64(len(transcription)) {
+		t.Errorf("expected BytesReceived %d, got %d", len(transcription), m.BytesReceived)
+	}
+	if m.Duration <= 0 {
+		t.Errorf("expected positive duration, got %v", m.Duration)
+	}
+
+	res, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoError(t, err, "CreateSpeech error")
+	if len(recorded) != 1 {
+		t.Fatal("speech metrics must not be reported before the body is closed")
+	}
+	_, err = io.ReadAll(res)
+	checks.NoError(t, err, "ReadAll error")
+	res.Close()
+	if len(recorded) != 2 {
+		t.Fatalf("expected 2 metrics records after speech, got %d", len(recorded))
+	}
+	if m = recorded[1]; m.Endpoint != "/audio/speech" || m.BytesReceived != int64(len(speech)) {
+		t.Errorf("unexpected speech metrics: %+v", m)
+	}
+}
+
+func TestAudioMetricsTransportError(t *testing.T) {
+	var recorded []openai.AudioCallMetrics
+	errTransport := errors.New("transport failure")
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.HTTPClient = &failingDoer{err: errTransport}
+	config.AudioMetricsFunc = func(m openai.AudioCallMetrics) {
+		recorded = append(recorded, m)
+	}
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.ErrorIs(t, err, errTransport, "CreateSpeech should return the transport error")
+	if len(recorded) != 1 || !errors.Is(recorded[0].Err, errTransport) || recorded[0].StatusCode != 0 {
+		t.Fatalf("unexpected metrics for transport error: %+v", recorded)
+	}
+}
+
+type failingDoer struct{ err error }
+
+func (d *failingDoer) Do(_ *http.Request) (*http.Response, error) {
+	return nil, d.err
+}
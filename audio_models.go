@@ -0,0 +1,35 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownModel = errors.New("model is not in ClientConfig.KnownModels")
+)
+
+// DefaultKnownModels returns the audio models this package defines constants for, to seed
+// ClientConfig.KnownModels. The returned map is a fresh copy that can be extended freely.
+func DefaultKnownModels() map[string]bool {
+	return map[string]bool{
+		Whisper1:                  true,
+		string(TTSModel1):         true,
+		string(TTSModel1HD):       true,
+		string(TTSModelCanary):    true,
+		string(TTSModelGPT4oMini): true,
+	}
+}
+
+// checkKnownModel returns ErrUnknownModel when ClientConfig.KnownModels is set and neither lists
+// model nor is overridden by ClientConfig.AllowCustomModel.
+func (c *Client) checkKnownModel(model string) error {
+	known := c.config.KnownModels
+	if known == nil || known[model] {
+		return nil
+	}
+	if allow := c.config.AllowCustomModel; allow != nil && allow(model) {
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownModel, model)
+}
@@ -0,0 +1,72 @@
+package openai_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestKnownModels(t *testing.T) {
+	server := test.NewTestServer()
+	calls := 0
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("audio"))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.KnownModels = openai.DefaultKnownModels()
+	config.AllowCustomModel = func(model string) bool { return strings.HasPrefix(model, "proxy/") }
+	client := openai.NewClientWithConfig(config)
+
+	transcribe := func(model string) error {
+		_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:    model,
+			FilePath: "audio.mp3",
+			Reader:   strings.NewReader("audio"),
+		})
+		return err
+	}
+	speak := func(model openai.SpeechModel) error {
+		response, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+			Model: model,
+			Input: "Hello!",
+			Voice: openai.VoiceAlloy,
+		})
+		if err == nil {
+			response.Close()
+		}
+		return err
+	}
+
+	checks.NoError(t, transcribe(openai.Whisper1), "expected a known transcription model to be accepted")
+	checks.NoError(t, speak(openai.TTSModel1), "expected a known speech model to be accepted")
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+
+	checks.ErrorIs(t, transcribe("whisper-l"), openai.ErrUnknownModel, "expected a typo to be rejected")
+	checks.ErrorIs(t, speak("tts-l"), openai.ErrUnknownModel, "expected a typo to be rejected")
+	if calls != 2 {
+		t.Fatalf("expected unknown models to be rejected before uploading, got %d requests", calls)
+	}
+
+	checks.NoError(t, transcribe("proxy/whisper-large-v3"), "expected a custom model to be allowed")
+	checks.NoError(t, speak("proxy/voice"), "expected a custom model to be allowed")
+	if calls != 4 {
+		t.Errorf("expected 4 requests, got %d", calls)
+	}
+}
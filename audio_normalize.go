@@ -0,0 +1,105 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrNormalizeUnsupportedFormat = errors.New("audio normalization is only supported for 16-bit PCM WAV or raw PCM input")
+)
+
+// AudioNormalization selects how AudioRequest.NormalizeAudio adjusts the loudness of the input.
+type AudioNormalization string
+
+const (
+	// AudioNormalizationPeak scales the audio so that its loudest sample reaches -1 dBFS.
+	AudioNormalizationPeak AudioNormalization = "peak"
+	// AudioNormalizationRMS scales the audio to an average level of -20 dBFS, as far as possible
+	// without clipping its loudest sample.
+	AudioNormalizationRMS AudioNormalization = "rms"
+)
+
+const (
+	normalizePeakTarget = 0.891 // -1 dBFS
+	normalizeRMSTarget  = 0.1   // -20 dBFS
+	pcm16FullScale      = math.MaxInt16
+	pcm16SampleSize     = 2
+)
+
+// normalizeAudioRequest returns a copy of the request whose input is scaled as selected by
+// NormalizeAudio, with NormalizeAudio cleared. The input is processed in memory.
+func normalizeAudioRequest(request AudioRequest) (AudioRequest, error) {
+	if request.NormalizeAudio != AudioNormalizationPeak && request.NormalizeAudio != AudioNormalizationRMS {
+		return AudioRequest{}, fmt.Errorf("unknown audio normalization %q", request.NormalizeAudio)
+	}
+	rawPCM := request.isRawPCM()
+	if rawPCM && request.InputEncoding != "" && request.InputEncoding != defaultPCMEncoding {
+		return AudioRequest{}, fmt.Errorf("%w, got %s", ErrNormalizeUnsupportedFormat, request.InputEncoding)
+	}
+	data, err := readAudioInput(request)
+	if err != nil {
+		return AudioRequest{}, err
+	}
+
+	if rawPCM {
+		data = normalizePCM16(data, request.NormalizeAudio)
+	} else {
+		if !isWAV(data) {
+			return AudioRequest{}, ErrNormalizeUnsupportedFormat
+		}
+		wav, parseErr := parseWAV(data)
+		if parseErr != nil {
+			return AudioRequest{}, parseErr
+		}
+		if wav.AudioFormat != wavFormatPCM || wav.BitsPerSample != 16 {
+			return AudioRequest{}, fmt.Errorf("%w, got format %d with %d bits",
+				ErrNormalizeUnsupportedFormat, wav.AudioFormat, wav.BitsPerSample)
+		}
+		wav.Data = normalizePCM16(wav.Data, request.NormalizeAudio)
+		data = wav.encode()
+	}
+
+	request.Reader = bytes.NewReader(data)
+	request.DataURI = ""
+	request.FilePath, request.Compressed = request.uploadFilename(), false
+	request.NormalizeAudio = ""
+	return request, nil
+}
+
+// normalizePCM16 scales little-endian 16-bit samples in place, which is safe as readAudioInput
+// returns a fresh copy of the input, and returns them. Silence is left as is.
+func normalizePCM16(data []byte, mode AudioNormalization) []byte {
+	count := len(data) / pcm16SampleSize
+	if count == 0 {
+		return data
+	}
+	sample := func(i int) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(data[i*pcm16SampleSize:]))) / pcm16FullScale
+	}
+
+	var peak, squares float64
+	for i := 0; i < count; i++ {
+		value := sample(i)
+		peak = math.Max(peak, math.Abs(value))
+		squares += value * value
+	}
+	if peak == 0 {
+		return data
+	}
+	gain := normalizePeakTarget / peak
+	if mode == AudioNormalizationRMS {
+		// Never clip: the loudest sample bounds the gain.
+		gain = math.Min(normalizeRMSTarget/math.Sqrt(squares/float64(count)), 1/peak)
+	}
+
+	for i := 0; i < count; i++ {
+		scaled := math.Round(sample(i) * gain * pcm16FullScale)
+		scaled = math.Max(math.Min(scaled, math.MaxInt16), math.MinInt16)
+		binary.LittleEndian.PutUint16(data[i*pcm16SampleSize:], uint16(int16(scaled)))
+	}
+	return data
+}
@@ -0,0 +1,67 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestNormalizeAudio(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var uploaded []byte
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, _ := r.FormFile("file")
+		uploaded, _ = io.ReadAll(file)
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	samplesOf := func(pcm []byte) []int16 {
+		samples := make([]int16, len(pcm)/2)
+		_ = binary.Read(bytes.NewReader(pcm), binary.LittleEndian, samples)
+		return samples
+	}
+
+	quiet := []int16{1000, -2000, 500, 0}
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:          openai.Whisper1,
+		FilePath:       "quiet.wav",
+		Reader:         bytes.NewReader(testWAV(8000, 1, quiet)),
+		NormalizeAudio: openai.AudioNormalizationPeak,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	// The loudest sample is scaled to -1 dBFS, the others by the same gain.
+	expected := []int16{14598, -29195, 7299, 0}
+	if got := samplesOf(uploaded[44:]); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected peak-normalized samples %v, expected %v", got, expected)
+	}
+
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:           openai.Whisper1,
+		FilePath:        "quiet.pcm",
+		Reader:          bytes.NewReader(testWAV(8000, 1, quiet)[44:]),
+		InputSampleRate: 8000,
+		InputChannels:   1,
+		NormalizeAudio:  openai.AudioNormalizationRMS,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	// The RMS of 1145.6 is raised to 3277 (-20 dBFS).
+	expected = []int16{2860, -5720, 1430, 0}
+	if got := samplesOf(uploaded); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected RMS-normalized samples %v, expected %v", got, expected)
+	}
+
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:          openai.Whisper1,
+		FilePath:       "speech.mp3",
+		Reader:         bytes.NewReader([]byte("ID3 not pcm")),
+		NormalizeAudio: openai.AudioNormalizationPeak,
+	})
+	checks.ErrorIs(t, err, openai.ErrNormalizeUnsupportedFormat, "expected compressed input to be rejected")
+}
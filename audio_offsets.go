@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrByteOffsetsUnsupportedFormat = errors.New("byte offsets are only supported for uncompressed WAV audio")
+	ErrInvalidFrameRate             = errors.New("frame rate must be a positive number")
+)
+
+// PCMLayout describes where the samples of uncompressed audio are stored, so that timestamps can
+// be converted into byte offsets. Build it with WAVLayout for WAV files; for headerless PCM,
+// DataOffset is 0.
+type PCMLayout struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+
+	DataOffset int64 // position of the first sample in the file
+	DataSize   int64 // size of the samples, 0 when unknown; offsets are clamped to it
+}
+
+// WAVLayout reads the layout of a WAV file from its bytes. Non-WAV input and compressed WAV
+// encodings are rejected with ErrByteOffsetsUnsupportedFormat, as their bytes do not map
+// linearly to time.
+func WAVLayout(wav []byte) (PCMLayout, error) {
+	if !isWAV(wav) {
+		return PCMLayout{}, ErrByteOffsetsUnsupportedFormat
+	}
+	parsed, err := parseWAV(wav)
+	if err != nil {
+		return PCMLayout{}, err
+	}
+	if parsed.AudioFormat != wavFormatPCM && parsed.AudioFormat != wavFormatFloat {
+		return PCMLayout{}, fmt.Errorf("%w: audio format %d", ErrByteOffsetsUnsupportedFormat, parsed.AudioFormat)
+	}
+	return PCMLayout{
+		SampleRate:    parsed.SampleRate,
+		Channels:      parsed.Channels,
+		BitsPerSample: parsed.BitsPerSample,
+		DataOffset:    int64(parsed.DataOffset),
+		DataSize:      int64(len(parsed.Data)),
+	}, nil
+}
+
+// ByteOffset returns the offset in the file of the frame playing at seconds, rounded down to a
+// frame boundary so that it can be used to seek.
+func (l PCMLayout) ByteOffset(seconds float64) int64 {
+	frameSize := int64(l.Channels * l.BitsPerSample / 8)
+	if frameSize <= 0 || l.SampleRate <= 0 {
+		return l.DataOffset
+	}
+	offset := int64(math.Max(seconds, 0)*float64(l.SampleRate)) * frameSize
+	if l.DataSize > 0 && offset > l.DataSize {
+		offset = l.DataSize - l.DataSize%frameSize
+	}
+	return l.DataOffset + offset
+}
+
+// SegmentByteRange is a segment together with the bytes of the audio it was transcribed from.
+type SegmentByteRange struct {
+	Segment AudioSegment
+	Start   int64 // offset of the first byte
+	End     int64 // offset after the last byte
+}
+
+// SegmentByteRanges pairs every segment with the range of audio bytes it covers according to
+// layout, e.g. to play back or highlight a segment. Offsets are approximate: they are only as
+// precise as the segment timestamps.
+func (r AudioResponse) SegmentByteRanges(layout PCMLayout) []SegmentByteRange {
+	ranges := make([]SegmentByteRange, len(r.Segments))
+	for i, segment := range r.Segments {
+		ranges[i] = SegmentByteRange{
+			Segment: segment,
+			Start:   layout.ByteOffset(segment.Start),
+			End:     layout.ByteOffset(segment.End),
+		}
+	}
+	return ranges
+}
+
+// SegmentFrameRange is a segment together with the video frames it spans.
+type SegmentFrameRange struct {
+	Segment AudioSegment
+	Start   int64 // frame showing when the segment starts
+	End     int64 // frame showing when the segment ends, first frame after the segment
+}
+
+// SegmentsAsFrames converts the timings of every segment into frame numbers at fps frames per
+// second, e.g. to burn subtitles into video. Frames are numbered from 0 and timestamps are rounded
+// to the nearest frame, as subtitle editors do; negative timestamps map to frame 0. Pass the exact
+// rate for drop-frame video, e.g. 30000/1001.0 rather than 29.97, to avoid drift on long videos.
+// A fps that is not a positive number fails with ErrInvalidFrameRate.
+func (r AudioResponse) SegmentsAsFrames(fps float64) ([]SegmentFrameRange, error) {
+	if fps <= 0 || math.IsNaN(fps) || math.IsInf(fps, 1) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFrameRate, fps)
+	}
+	frame := func(seconds float64) int64 {
+		return int64(math.Round(math.Max(seconds, 0) * fps))
+	}
+	ranges := make([]SegmentFrameRange, len(r.Segments))
+	for i, segment := range r.Segments {
+		ranges[i] = SegmentFrameRange{
+			Segment: segment,
+			Start:   frame(segment.Start),
+			End:     frame(segment.End),
+		}
+	}
+	return ranges, nil
+}
@@ -0,0 +1,82 @@
+package openai_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestSegmentByteRanges(t *testing.T) {
+	// 2 seconds of 16 kHz stereo 16-bit audio: 64000 bytes per second after the 44 byte header.
+	wav := testWAV(16000, 2, make([]int16, 2*16000*2))
+	layout, err := openai.WAVLayout(wav)
+	checks.NoError(t, err, "WAVLayout error")
+	expectedLayout := openai.PCMLayout{SampleRate: 16000, Channels: 2, BitsPerSample: 16, DataOffset: 44, DataSize: 128000}
+	if layout != expectedLayout {
+		t.Fatalf("expected layout %+v, got %+v", expectedLayout, layout)
+	}
+
+	res := openai.AudioResponse{Segments: []openai.AudioSegment{
+		{ID: 0, Start: 0, End: 0.5},
+		{ID: 1, Start: 0.5, End: 1.25},
+		{ID: 2, Start: 1.9, End: 3}, // ends past the audio
+	}}
+	ranges := res.SegmentByteRanges(layout)
+	expected := []openai.SegmentByteRange{
+		{Segment: res.Segments[0], Start: 44, End: 44 + 32000},
+		{Segment: res.Segments[1], Start: 44 + 32000, End: 44 + 80000},
+		{Segment: res.Segments[2], Start: 44 + 121600, End: 44 + 128000},
+	}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("expected %+v, got %+v", expected, ranges)
+	}
+
+	// Offsets are aligned on frames, 4 bytes here.
+	if offset := layout.ByteOffset(1.0 / 48000); (offset-44)%4 != 0 {
+		t.Errorf("offset %d is not frame aligned", offset)
+	}
+
+	pcm := openai.PCMLayout{SampleRate: 8000, Channels: 1, BitsPerSample: 16}
+	if offset := pcm.ByteOffset(10); offset != 160000 {
+		t.Errorf("expected headerless PCM offset 160000, got %d", offset)
+	}
+}
+
+func TestWAVLayoutUnsupportedFormat(t *testing.T) {
+	_, err := openai.WAVLayout([]byte("ID3\x04mp3 frames"))
+	checks.ErrorIs(t, err, openai.ErrByteOffsetsUnsupportedFormat, "mp3 should be rejected")
+
+	adpcm := testWAV(8000, 1, make([]int16, 8))
+	adpcm[20] = 2 // fmt audio format: Microsoft ADPCM
+	_, err = openai.WAVLayout(adpcm)
+	checks.ErrorIs(t, err, openai.ErrByteOffsetsUnsupportedFormat, "ADPCM should be rejected")
+}
+
+func TestSegmentsAsFrames(t *testing.T) {
+	res := openai.AudioResponse{Segments: []openai.AudioSegment{
+		{ID: 0, Start: -0.01, End: 1.5},
+		{ID: 1, Start: 1.5, End: 62.04},
+	}}
+	cases := []struct {
+		fps      float64
+		expected [][2]int64
+	}{
+		{24, [][2]int64{{0, 36}, {36, 1489}}},
+		{25, [][2]int64{{0, 38}, {38, 1551}}}, // 37.5 rounds up
+		{29.97, [][2]int64{{0, 45}, {45, 1859}}},
+	}
+	for _, c := range cases {
+		ranges, err := res.SegmentsAsFrames(c.fps)
+		checks.NoError(t, err, "SegmentsAsFrames error")
+		for i, r := range ranges {
+			if r.Segment.ID != i || r.Start != c.expected[i][0] || r.End != c.expected[i][1] {
+				t.Errorf("at %v fps, expected segment %d at frames %v, got %+v", c.fps, i, c.expected[i], r)
+			}
+		}
+	}
+
+	_, err := res.SegmentsAsFrames(0)
+	checks.ErrorIs(t, err, openai.ErrInvalidFrameRate, "expected a zero frame rate to be rejected")
+}
@@ -0,0 +1,603 @@
+package openai
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MergeBySpeaker merges consecutive segments attributed to the same Speaker into a single
+// segment spanning all of them. Texts are joined with a single space, or concatenated verbatim
+// when PreserveWhitespace is set. The receiver is not modified.
+func (r AudioResponse) MergeBySpeaker() []AudioSegment {
+	return r.mergeSegments(func(last, next AudioSegment, _ string) bool {
+		return last.Speaker == next.Speaker
+	})
+}
+
+// MergeToLength merges consecutive segments as long as the merged text stays within maxChars
+// characters, e.g. to build subtitle cues of a readable length out of short fragments. Segments of
+// different speakers are never merged, and a segment longer than maxChars is kept as is. Texts are
+// joined as in MergeBySpeaker. The receiver is not modified.
+func (r AudioResponse) MergeToLength(maxChars int) []AudioSegment {
+	return r.mergeSegments(func(last, next AudioSegment, text string) bool {
+		return last.Speaker == next.Speaker && utf8.RuneCountInString(text) <= maxChars
+	})
+}
+
+// mergeSegments merges every segment into the previous merged one when accept returns true for
+// them and the joined text, renumbering the resulting segments.
+func (r AudioResponse) mergeSegments(accept func(last, next AudioSegment, text string) bool) []AudioSegment {
+	var merged []AudioSegment
+	for _, segment := range r.Segments {
+		text := r.normalizeSpace(segment.Text)
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if joined := r.joinText(last.Text, text); accept(*last, segment, joined) {
+				last.End = segment.End
+				last.Text = joined
+				continue
+			}
+		}
+		segment.ID = len(merged)
+		segment.Text = text
+		segment.Tokens = nil
+		merged = append(merged, segment)
+	}
+	return merged
+}
+
+// joinText appends text to merged segment text.
+func (r AudioResponse) joinText(merged, text string) string {
+	switch {
+	case r.PreserveWhitespace:
+		return merged + text
+	case text != "":
+		return strings.TrimSpace(merged + " " + text)
+	default:
+		return merged
+	}
+}
+
+// DialogueText renders the transcript as one "Label: text" line per speaker turn.
+// labels maps Speaker IDs to human readable names; IDs missing from labels are used as-is,
+// and turns without a speaker are rendered as plain text.
+func (r AudioResponse) DialogueText(labels map[string]string) string {
+	var sb strings.Builder
+	for i, turn := range r.MergeBySpeaker() {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		label, ok := labels[turn.Speaker]
+		if !ok {
+			label = turn.Speaker
+		}
+		if label != "" {
+			sb.WriteString(label)
+			sb.WriteString(": ")
+		}
+		sb.WriteString(turn.Text)
+	}
+	return sb.String()
+}
+
+// SpeakerCount returns the number of distinct non-empty Speaker IDs across the segments, i.e. 0
+// when the transcription was not diarized.
+func (r AudioResponse) SpeakerCount() int {
+	speakers := make(map[string]bool)
+	for _, segment := range r.Segments {
+		if segment.Speaker != "" {
+			speakers[segment.Speaker] = true
+		}
+	}
+	return len(speakers)
+}
+
+// segmentsText joins the text of segments the way Whisper builds AudioResponse.Text.
+func (r AudioResponse) segmentsText(segments []AudioSegment) string {
+	var sb strings.Builder
+	for _, segment := range segments {
+		sb.WriteString(segment.Text)
+	}
+	return r.normalizeSpace(sb.String())
+}
+
+// normalizeSpace trims s unless the request asked to preserve whitespace.
+func (r AudioResponse) normalizeSpace(s string) string {
+	if r.PreserveWhitespace {
+		return s
+	}
+	return strings.TrimSpace(s)
+}
+
+// Redact returns a copy of the response in which every whole-word, case-insensitive occurrence
+// of words is replaced by mask in Segments and Words, and Text is recomputed from the segments.
+// An empty mask replaces each letter with '*'. Timings are left untouched. Word boundaries are
+// Unicode aware; words in scripts written without spaces, such as Chinese, match anywhere.
+func (r AudioResponse) Redact(words []string, mask string) AudioResponse {
+	if len(words) == 0 {
+		return r
+	}
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quoted) == 0 {
+		return r
+	}
+	// Prefer the longest alternative, so that a shorter word does not hide a longer whole word.
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	pattern := regexp.MustCompile(`(?i)(?:` + strings.Join(quoted, "|") + `)`)
+	replace := func(s string) string {
+		var sb strings.Builder
+		last := 0
+		for _, loc := range pattern.FindAllStringIndex(s, -1) {
+			if !isWholeWord(s, loc[0], loc[1]) {
+				continue
+			}
+			sb.WriteString(s[last:loc[0]])
+			if mask == "" {
+				sb.WriteString(strings.Repeat("*", utf8.RuneCountInString(s[loc[0]:loc[1]])))
+			} else {
+				sb.WriteString(mask)
+			}
+			last = loc[1]
+		}
+		sb.WriteString(s[last:])
+		return sb.String()
+	}
+
+	redacted := r
+	if r.Segments != nil {
+		redacted.Segments = make([]AudioSegment, len(r.Segments))
+		for i, segment := range r.Segments {
+			segment.Text = replace(segment.Text)
+			redacted.Segments[i] = segment
+		}
+	}
+	if r.Words != nil {
+		redacted.Words = make([]AudioWord, len(r.Words))
+		for i, word := range r.Words {
+			word.Word = replace(word.Word)
+			redacted.Words[i] = word
+		}
+	}
+	if len(redacted.Segments) > 0 {
+		redacted.Text = redacted.segmentsText(redacted.Segments)
+	} else {
+		redacted.Text = replace(r.Text)
+	}
+	return redacted
+}
+
+// isWholeWord reports whether s[start:end] is not glued to letters or digits on either side.
+// Edges written in scripts that do not separate words with spaces, such as Chinese or Japanese,
+// are always word boundaries.
+func isWholeWord(s string, start, end int) bool {
+	first, _ := utf8.DecodeRuneInString(s[start:end])
+	if before, _ := utf8.DecodeLastRuneInString(s[:start]); start > 0 && isWordRune(before) && !isUnspacedRune(first) {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(s[start:end])
+	if after, _ := utf8.DecodeRuneInString(s[end:]); end < len(s) && isWordRune(after) && !isUnspacedRune(last) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// isUnspacedRune reports whether r belongs to a script written without spaces between words.
+func isUnspacedRune(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer)
+}
+
+// RemoveRepetitions returns a copy of the response in which every run of more than minRepeat
+// consecutive segments with the same text and Speaker is collapsed into its first segment, removing
+// the hallucination loops Whisper sometimes produces at the end of audio. Texts are compared ignoring
+// case, punctuation and whitespace, so that near-identical repetitions are caught too. Words timed
+// within the removed segments are removed as well, Text is recomputed from the segments and
+// segments are renumbered. A minRepeat below 1 is treated as 1. A response without such runs is
+// returned unchanged.
+func (r AudioResponse) RemoveRepetitions(minRepeat int) AudioResponse {
+	if minRepeat < 1 {
+		minRepeat = 1
+	}
+	var kept, removed []AudioSegment
+	for start := 0; start < len(r.Segments); {
+		key := repetitionKey(r.Segments[start].Text)
+		end := start + 1
+		for end < len(r.Segments) && r.Segments[end].Speaker == r.Segments[start].Speaker &&
+			repetitionKey(r.Segments[end].Text) == key {
+			end++
+		}
+		if end-start > minRepeat {
+			kept = append(kept, r.Segments[start])
+			removed = append(removed, r.Segments[start+1:end]...)
+		} else {
+			kept = append(kept, r.Segments[start:end]...)
+		}
+		start = end
+	}
+	if len(removed) == 0 {
+		return r
+	}
+
+	collapsed := r
+	for i := range kept {
+		kept[i].ID = i
+	}
+	collapsed.Segments = kept
+	if r.Words != nil {
+		collapsed.Words = make([]AudioWord, 0, len(r.Words))
+		for _, word := range r.Words {
+			if !withinSegments(removed, (word.Start+word.End)/2) {
+				collapsed.Words = append(collapsed.Words, word)
+			}
+		}
+	}
+	collapsed.Text = collapsed.segmentsText(kept)
+	return collapsed
+}
+
+// repetitionKey normalizes the text of a segment to detect repetitions.
+func repetitionKey(text string) string {
+	words := strings.Fields(text)
+	keys := make([]string, 0, len(words))
+	for _, word := range words {
+		if key := alignmentKey(word); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+// withinSegments reports whether the timestamp, in seconds, lies within one of the segments.
+func withinSegments(segments []AudioSegment, seconds float64) bool {
+	for _, segment := range segments {
+		if seconds >= segment.Start && seconds < segment.End {
+			return true
+		}
+	}
+	return false
+}
+
+// TextBetween returns the transcript spoken between start and end. When Words are available it
+// joins the words lying entirely within the range, so that words cut by the boundaries are left
+// out; otherwise it joins the text of every segment overlapping the range. It returns "" when
+// nothing was said in the range.
+func (r AudioResponse) TextBetween(start, end time.Duration) string {
+	from, to := start.Seconds(), end.Seconds()
+	if to <= from {
+		return ""
+	}
+	if len(r.Words) > 0 {
+		var words []string
+		for _, word := range r.Words {
+			if word.Start >= from && word.End <= to {
+				words = append(words, strings.TrimSpace(word.Word))
+			}
+		}
+		return strings.Join(words, " ")
+	}
+	var segments []AudioSegment
+	for _, segment := range r.Segments {
+		if segment.End > from && segment.Start < to {
+			segments = append(segments, segment)
+		}
+	}
+	return r.segmentsText(segments)
+}
+
+// SilenceGap is a pause between two consecutive segments, in seconds.
+type SilenceGap struct {
+	Start float64
+	End   float64
+}
+
+// Duration returns the length of the gap.
+func (g SilenceGap) Duration() time.Duration {
+	return secondsToDuration(g.End - g.Start)
+}
+
+// SilenceGaps returns the pauses between consecutive segments that last at least minGap.
+// It returns nil when there are fewer than two segments or no gap is long enough.
+func (r AudioResponse) SilenceGaps(minGap time.Duration) []SilenceGap {
+	var gaps []SilenceGap
+	for i := 1; i < len(r.Segments); i++ {
+		gap := SilenceGap{Start: r.Segments[i-1].End, End: r.Segments[i].Start}
+		if gap.End > gap.Start && gap.Duration() >= minGap {
+			gaps = append(gaps, gap)
+		}
+	}
+	return gaps
+}
+
+// FixOverlaps returns a copy of the response whose segments follow each other without overlapping,
+// as required by most subtitle players. A segment starting before the previous one ends clips the
+// End of the previous segment; when that would end the previous segment before it starts, the
+// segment is pushed back to start where the previous one ends instead. Ends never precede starts.
+// A response without overlaps is returned unchanged.
+func (r AudioResponse) FixOverlaps() AudioResponse {
+	clean := true
+	for i := 1; i < len(r.Segments) && clean; i++ {
+		clean = r.Segments[i].Start >= r.Segments[i-1].End
+	}
+	if clean {
+		return r
+	}
+
+	fixed := r
+	fixed.Segments = make([]AudioSegment, len(r.Segments))
+	copy(fixed.Segments, r.Segments)
+	for i := 1; i < len(fixed.Segments); i++ {
+		previous, current := &fixed.Segments[i-1], &fixed.Segments[i]
+		if current.Start >= previous.End {
+			continue
+		}
+		if current.Start >= previous.Start {
+			previous.End = current.Start
+		} else {
+			current.Start = previous.End
+		}
+		if current.End < current.Start {
+			current.End = current.Start
+		}
+	}
+	return fixed
+}
+
+// TurnStats summarizes the turn-taking of a diarized conversation, see AudioResponse.TurnStats.
+type TurnStats struct {
+	// Turns is the number of speaker turns, consecutive segments of the same speaker being one turn.
+	Turns int
+	// AverageTurnLength is the mean duration of the turns.
+	AverageTurnLength time.Duration
+	// Overlaps counts the segments starting before the segment of another speaker preceding them ends.
+	Overlaps int
+	// Interruptions counts the overlaps that outlast the overlapped segment, i.e. the new speaker
+	// takes the floor, unlike backchannels such as "mm-hm" that end while the other speaker talks.
+	Interruptions int
+}
+
+// TurnStats computes turn-taking metrics over the diarized segments. Overlaps are counted on the
+// segments as returned by the API; turns are then built with FixOverlaps and MergeBySpeaker so
+// that overlapping speech is not counted twice. A response without Speaker IDs returns zero stats.
+func (r AudioResponse) TurnStats() TurnStats {
+	if r.SpeakerCount() == 0 {
+		return TurnStats{}
+	}
+
+	var stats TurnStats
+	for i := 1; i < len(r.Segments); i++ {
+		previous, current := r.Segments[i-1], r.Segments[i]
+		if current.Speaker == previous.Speaker || current.Start >= previous.End {
+			continue
+		}
+		stats.Overlaps++
+		if current.End > previous.End {
+			stats.Interruptions++
+		}
+	}
+
+	turns := r.FixOverlaps().MergeBySpeaker()
+	var total float64
+	for _, turn := range turns {
+		total += turn.End - turn.Start
+	}
+	stats.Turns = len(turns)
+	stats.AverageTurnLength = secondsToDuration(total / float64(len(turns)))
+	return stats
+}
+
+// BuildSegmentsFromWords returns a copy of the response whose Segments are built from Words, for
+// backends that only time words. A new segment starts when the pause before a word exceeds
+// maxGap, when adding the word would make the text longer than maxChars, or when the speaker
+// changes; a zero maxGap or maxChars disables that rule. A word longer than maxChars gets a segment
+// of its own. The response is returned unchanged when it already has segments or has no words.
+func (r AudioResponse) BuildSegmentsFromWords(maxGap time.Duration, maxChars int) AudioResponse {
+	if len(r.Segments) > 0 || len(r.Words) == 0 {
+		return r
+	}
+
+	built := r
+	var segments []AudioSegment
+	var text string
+	for i, word := range r.Words {
+		token := strings.TrimSpace(word.Word)
+		if i > 0 {
+			last := &segments[len(segments)-1]
+			split := word.Speaker != last.Speaker ||
+				(maxGap > 0 && secondsToDuration(word.Start-last.End) > maxGap) ||
+				(maxChars > 0 && utf8.RuneCountInString(text)+1+utf8.RuneCountInString(token) > maxChars)
+			if !split {
+				text += " " + token
+				last.End = word.End
+				last.Text = " " + text
+				continue
+			}
+		}
+		text = token
+		segments = append(segments, AudioSegment{
+			ID:      len(segments),
+			Start:   word.Start,
+			End:     word.End,
+			Text:    " " + text,
+			Speaker: word.Speaker,
+		})
+	}
+	built.Segments = segments
+	return built
+}
+
+// secondsToDuration converts a timestamp in seconds, as used by the API, to a time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
+
+// DominantSentiment returns the Sentiment covering the largest share of the speech, weighting every
+// segment by its duration (or counting segments when none has a duration), together with that share
+// in [0, 1]. Segments without a sentiment are ignored; ties resolve to the alphabetically first label.
+// It returns ("", 0) when no segment carries a sentiment.
+func (r AudioResponse) DominantSentiment() (string, float64) {
+	weights := make(map[string]float64)
+	var total float64
+	byDuration := false
+	for _, segment := range r.Segments {
+		if segment.Sentiment != "" && segment.End > segment.Start {
+			byDuration = true
+			break
+		}
+	}
+	for _, segment := range r.Segments {
+		if segment.Sentiment == "" {
+			continue
+		}
+		weight := 1.0
+		if byDuration {
+			weight = math.Max(segment.End-segment.Start, 0)
+		}
+		weights[segment.Sentiment] += weight
+		total += weight
+	}
+	if total == 0 {
+		return "", 0
+	}
+
+	var dominant string
+	for sentiment, weight := range weights {
+		if dominant == "" || weight > weights[dominant] || (weight == weights[dominant] && sentiment < dominant) {
+			dominant = sentiment
+		}
+	}
+	return dominant, weights[dominant] / total
+}
+
+// SpeechRateStats summarizes how much and how fast a transcript is spoken.
+type SpeechRateStats struct {
+	Words          int
+	VoicedDuration time.Duration // total speaking time, silence gaps between segments excluded
+	WordsPerMinute float64       // Words over VoicedDuration, 0 when nothing is voiced
+
+	// Speakers breaks the statistics down per Speaker when segments are diarized, nil otherwise.
+	Speakers map[string]SpeechRateStats
+}
+
+// SpeechRate returns word count, talk time and speaking rate of the transcript. Voiced time is
+// the union of segment spans (or word spans when there are no segments), so overlapping speech is
+// counted once in the total. Words come from Words when present, each attributed to the segment
+// containing its midpoint, otherwise from splitting Segment.Text (or Text) on whitespace.
+func (r AudioResponse) SpeechRate() SpeechRateStats {
+	words := make([]int, len(r.Segments))
+	unattributed := 0
+	switch {
+	case len(r.Words) > 0:
+		for _, word := range r.Words {
+			if i := r.segmentAt((word.Start + word.End) / 2); i >= 0 {
+				words[i]++
+			} else {
+				unattributed++
+			}
+		}
+	case len(r.Segments) > 0:
+		for i, segment := range r.Segments {
+			words[i] = len(strings.Fields(segment.Text))
+		}
+	default:
+		unattributed = len(strings.Fields(r.Text))
+	}
+
+	var spans []timeSpan
+	if len(r.Segments) > 0 {
+		for _, segment := range r.Segments {
+			spans = append(spans, timeSpan{start: segment.Start, end: segment.End})
+		}
+	} else {
+		for _, word := range r.Words {
+			spans = append(spans, timeSpan{start: word.Start, end: word.End})
+		}
+	}
+
+	stats := newSpeechRateStats(unattributed, spans)
+	speakerWords := make(map[string]int)
+	speakerSpans := make(map[string][]timeSpan)
+	for i, segment := range r.Segments {
+		stats.Words += words[i]
+		if segment.Speaker != "" {
+			speakerWords[segment.Speaker] += words[i]
+			speakerSpans[segment.Speaker] = append(speakerSpans[segment.Speaker], spans[i])
+		}
+	}
+	stats.WordsPerMinute = wordsPerMinute(stats.Words, stats.VoicedDuration)
+	if len(speakerSpans) > 0 {
+		stats.Speakers = make(map[string]SpeechRateStats, len(speakerSpans))
+		for speaker, spans := range speakerSpans {
+			stats.Speakers[speaker] = newSpeechRateStats(speakerWords[speaker], spans)
+		}
+	}
+	return stats
+}
+
+// segmentAt returns the index of the segment spanning the given time, or -1.
+func (r AudioResponse) segmentAt(seconds float64) int {
+	for i, segment := range r.Segments {
+		if seconds >= segment.Start && seconds <= segment.End {
+			return i
+		}
+	}
+	return -1
+}
+
+func newSpeechRateStats(words int, spans []timeSpan) SpeechRateStats {
+	voiced := unionDuration(spans)
+	return SpeechRateStats{
+		Words:          words,
+		VoicedDuration: voiced,
+		WordsPerMinute: wordsPerMinute(words, voiced),
+	}
+}
+
+func wordsPerMinute(words int, voiced time.Duration) float64 {
+	if voiced <= 0 {
+		return 0
+	}
+	return float64(words) / voiced.Minutes()
+}
+
+// timeSpan is an interval of a transcript, in seconds.
+type timeSpan struct {
+	start float64
+	end   float64
+}
+
+// unionDuration returns the total length covered by spans, counting overlaps once.
+func unionDuration(spans []timeSpan) time.Duration {
+	sorted := make([]timeSpan, 0, len(spans))
+	for _, span := range spans {
+		if span.end > span.start {
+			sorted = append(sorted, span)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+
+	var total float64
+	var current timeSpan
+	for i, span := range sorted {
+		if i > 0 && span.start <= current.end {
+			current.end = math.Max(current.end, span.end)
+			continue
+		}
+		total += current.end - current.start
+		current = span
+	}
+	total += current.end - current.start
+	return secondsToDuration(total)
+}
@@ -0,0 +1,444 @@
+package openai_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func diarizedResponse() openai.AudioResponse {
+	return openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{ID: 0, Start: 0, End: 1.5, Text: " Hello there.", Speaker: "spk_0"},
+			{ID: 1, Start: 1.5, End: 3, Text: " How are you?", Speaker: "spk_0"},
+			{ID: 2, Start: 3.2, End: 4, Text: " Fine, thanks.", Speaker: "spk_1"},
+			{ID: 3, Start: 4.5, End: 6, Text: " Great.", Speaker: "spk_0"},
+		},
+	}
+}
+
+func TestMergeBySpeaker(t *testing.T) {
+	merged := diarizedResponse().MergeBySpeaker()
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 turns, got %d", len(merged))
+	}
+	first := merged[0]
+	if first.Start != 0 || first.End != 3 || first.Text != "Hello there. How are you?" || first.Speaker != "spk_0" {
+		t.Errorf("unexpected first turn: %+v", first)
+	}
+	if merged[2].ID != 2 || merged[2].Text != "Great." {
+		t.Errorf("unexpected last turn: %+v", merged[2])
+	}
+}
+
+func TestSpeakerCount(t *testing.T) {
+	res := diarizedResponse()
+	if got := res.SpeakerCount(); got != 2 {
+		t.Errorf("expected 2 speakers, got %d", got)
+	}
+	res.Segments = append(res.Segments, openai.AudioSegment{Text: " Unattributed."})
+	if got := res.SpeakerCount(); got != 2 {
+		t.Errorf("expected segments without speaker to be ignored, got %d", got)
+	}
+	plain := openai.AudioResponse{Segments: []openai.AudioSegment{{Text: " Hello."}}}
+	if got := plain.SpeakerCount(); got != 0 {
+		t.Errorf("expected 0 speakers without diarization, got %d", got)
+	}
+}
+
+func TestBuildSegmentsFromWords(t *testing.T) {
+	res := openai.AudioResponse{
+		Words: []openai.AudioWord{
+			{Word: "Hello", Start: 0, End: 0.4},
+			{Word: "there.", Start: 0.5, End: 0.9},
+			{Word: "How", Start: 2, End: 2.2}, // after a long pause
+			{Word: "are", Start: 2.3, End: 2.4},
+			{Word: "you", Start: 2.5, End: 2.6},
+			{Word: "doing?", Start: 2.7, End: 3}, // too long for the current segment
+			{Word: "Fine.", Start: 3.1, End: 3.5, Speaker: "spk_1"},
+		},
+	}
+
+	built := res.BuildSegmentsFromWords(time.Second/2, 12)
+	expected := []openai.AudioSegment{
+		{ID: 0, Start: 0, End: 0.9, Text: " Hello there."},
+		{ID: 1, Start: 2, End: 2.6, Text: " How are you"},
+		{ID: 2, Start: 2.7, End: 3, Text: " doing?"},
+		{ID: 3, Start: 3.1, End: 3.5, Text: " Fine.", Speaker: "spk_1"},
+	}
+	if !reflect.DeepEqual(built.Segments, expected) {
+		t.Fatalf("unexpected segments:\n%+v\nexpected:\n%+v", built.Segments, expected)
+	}
+	if len(res.Segments) != 0 {
+		t.Error("expected the original response to be left untouched")
+	}
+
+	if got := res.BuildSegmentsFromWords(0, 0).Segments; len(got) != 2 {
+		t.Errorf("expected only speaker changes to split without limits, got %+v", got)
+	}
+
+	existing := diarizedResponse()
+	existing.Words = res.Words
+	if got := existing.BuildSegmentsFromWords(0, 0); !reflect.DeepEqual(got.Segments, diarizedResponse().Segments) {
+		t.Errorf("expected existing segments to be kept, got %+v", got.Segments)
+	}
+}
+
+func TestMergeToLength(t *testing.T) {
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{Start: 0, End: 1, Text: " One"},
+			{Start: 1, End: 2, Text: " two"},
+			{Start: 2, End: 3, Text: " three."}, // "One two three." is 14 characters
+			{Start: 3, End: 4, Text: " Four five six seven."},
+			{Start: 4, End: 5, Text: " Eight."},
+		},
+	}
+	merged := res.MergeToLength(14)
+	expected := []openai.AudioSegment{
+		{ID: 0, Start: 0, End: 3, Text: "One two three."},
+		{ID: 1, Start: 3, End: 4, Text: "Four five six seven."},
+		{ID: 2, Start: 4, End: 5, Text: "Eight."},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %+v, got %+v", expected, merged)
+	}
+	if short := res.MergeToLength(13); len(short) != 4 || short[0].Text != "One two" {
+		t.Errorf("expected the threshold to be respected, got %+v", short)
+	}
+
+	speakers := diarizedResponse().MergeToLength(1000)
+	if len(speakers) != 3 || speakers[1].Speaker != "spk_1" || speakers[1].Text != "Fine, thanks." {
+		t.Errorf("expected speaker turns to be kept apart, got %+v", speakers)
+	}
+}
+
+func TestDialogueText(t *testing.T) {
+	res := diarizedResponse()
+
+	t.Run("with labels", func(t *testing.T) {
+		got := res.DialogueText(map[string]string{"spk_0": "Alice", "spk_1": "Bob"})
+		want := "Alice: Hello there. How are you?\nBob: Fine, thanks.\nAlice: Great."
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("without labels", func(t *testing.T) {
+		got := res.DialogueText(nil)
+		want := "spk_0: Hello there. How are you?\nspk_1: Fine, thanks.\nspk_0: Great."
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("no speakers", func(t *testing.T) {
+		got := openai.AudioResponse{Segments: []openai.AudioSegment{{Text: " a"}, {Text: " b"}}}.DialogueText(nil)
+		if got != "a b" {
+			t.Errorf("expected plain text, got %q", got)
+		}
+	})
+}
+
+func TestRedact(t *testing.T) {
+	res := openai.AudioResponse{
+		Text: "Darn it. Darned dog, DARN!",
+		Segments: []openai.AudioSegment{
+			{Start: 0, End: 1, Text: " Darn it."},
+			{Start: 1, End: 2.5, Text: " Darned dog, DARN!"},
+		},
+		Words: []openai.AudioWord{{Word: "Darn", Start: 0, End: 0.5}, {Word: "Darned", Start: 1, End: 1.4}},
+	}
+
+	redacted := res.Redact([]string{"darn"}, "[bleep]")
+	if redacted.Text != "[bleep] it. Darned dog, [bleep]!" {
+		t.Errorf("unexpected redacted text %q", redacted.Text)
+	}
+	if redacted.Segments[1].Start != 1 || redacted.Segments[1].End != 2.5 {
+		t.Errorf("timings must not change: %+v", redacted.Segments[1])
+	}
+	if redacted.Words[0].Word != "[bleep]" || redacted.Words[1].Word != "Darned" {
+		t.Errorf("unexpected redacted words %+v", redacted.Words)
+	}
+	if res.Segments[0].Text != " Darn it." {
+		t.Error("Redact must not modify the receiver")
+	}
+
+	stars := res.Redact([]string{"dog"}, "")
+	if stars.Segments[1].Text != " Darned ***, DARN!" {
+		t.Errorf("unexpected default mask %q", stars.Segments[1].Text)
+	}
+
+	plain := openai.AudioResponse{Text: "what the heck"}.Redact([]string{"HECK"}, "#")
+	if plain.Text != "what the #" {
+		t.Errorf("unexpected text-only redaction %q", plain.Text)
+	}
+}
+
+func TestRedactUnicode(t *testing.T) {
+	res := openai.AudioResponse{Text: "Ärger an der École, kein Ärgernis. école!"}
+	redacted := res.Redact([]string{"ärger", "école"}, "***")
+	if redacted.Text != "*** an der ***, kein Ärgernis. ***!" {
+		t.Errorf("unexpected redacted text %q", redacted.Text)
+	}
+
+	cjk := openai.AudioResponse{Text: "这是秘密文件，不是秘密。"}.Redact([]string{"秘密"}, "")
+	if cjk.Text != "这是**文件，不是**。" {
+		t.Errorf("unexpected CJK redaction %q", cjk.Text)
+	}
+
+	longest := openai.AudioResponse{Text: "darn dar"}.Redact([]string{"dar", "darn"}, "#")
+	if longest.Text != "# #" {
+		t.Errorf("unexpected redaction of overlapping words %q", longest.Text)
+	}
+}
+
+func TestSilenceGaps(t *testing.T) {
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{Start: 0, End: 2},
+			{Start: 2.2, End: 4},
+			{Start: 5.5, End: 7},
+			{Start: 7, End: 8},
+			{Start: 11, End: 12},
+		},
+	}
+
+	gaps := res.SilenceGaps(time.Second)
+	expected := []openai.SilenceGap{{Start: 4, End: 5.5}, {Start: 8, End: 11}}
+	if !reflect.DeepEqual(gaps, expected) {
+		t.Fatalf("expected %v, got %v", expected, gaps)
+	}
+	if gaps[1].Duration() != 3*time.Second {
+		t.Errorf("unexpected gap duration %v", gaps[1].Duration())
+	}
+
+	if all := res.SilenceGaps(0); len(all) != 3 {
+		t.Errorf("expected 3 gaps with no minimum, got %v", all)
+	}
+	if none := res.SilenceGaps(5 * time.Second); none != nil {
+		t.Errorf("expected nil, got %v", none)
+	}
+	if none := (openai.AudioResponse{}).SilenceGaps(0); none != nil {
+		t.Errorf("expected nil without segments, got %v", none)
+	}
+}
+
+func TestFixOverlaps(t *testing.T) {
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{ID: 0, Start: 0, End: 2.5},
+			{ID: 1, Start: 2, End: 4},
+			{ID: 2, Start: 1.5, End: 3},
+			{ID: 3, Start: 6, End: 7},
+		},
+	}
+	fixed := res.FixOverlaps()
+	expected := []openai.AudioSegment{
+		{ID: 0, Start: 0, End: 2},
+		{ID: 1, Start: 2, End: 4},
+		{ID: 2, Start: 4, End: 4},
+		{ID: 3, Start: 6, End: 7},
+	}
+	if !reflect.DeepEqual(fixed.Segments, expected) {
+		t.Fatalf("expected %v, got %v", expected, fixed.Segments)
+	}
+	if res.Segments[0].End != 2.5 {
+		t.Errorf("FixOverlaps modified the original response")
+	}
+
+	clean := diarizedResponse()
+	if again := clean.FixOverlaps(); !reflect.DeepEqual(again, clean) {
+		t.Errorf("expected a clean response to be unchanged, got %v", again.Segments)
+	}
+}
+
+func TestTurnStats(t *testing.T) {
+	res := diarizedResponse()
+	// spk_1 cuts in before spk_0 is done, then backchannels during the last turn of spk_0.
+	res.Segments[2].Start = 2.8
+	res.Segments = append(res.Segments,
+		openai.AudioSegment{ID: 4, Start: 4.6, End: 4.8, Text: " Mm-hm.", Speaker: "spk_1"})
+
+	expected := openai.TurnStats{
+		Turns:             4,
+		AverageTurnLength: 1075 * time.Millisecond,
+		Overlaps:          2,
+		Interruptions:     1,
+	}
+	if stats := res.TurnStats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	plain := openai.AudioResponse{Segments: []openai.AudioSegment{{Start: 0, End: 1}, {Start: 0.5, End: 2}}}
+	if stats := plain.TurnStats(); stats != (openai.TurnStats{}) {
+		t.Errorf("expected zero stats without speakers, got %+v", stats)
+	}
+}
+
+func TestRemoveRepetitions(t *testing.T) {
+	looping := openai.AudioResponse{
+		Text: "Thanks for watching. Thanks for watching! thanks for watching Thanks for watching. Bye.",
+		Segments: []openai.AudioSegment{
+			{ID: 0, Start: 0, End: 2, Text: " Thanks for watching."},
+			{ID: 1, Start: 2, End: 4, Text: " Thanks for watching!"},
+			{ID: 2, Start: 4, End: 6, Text: " thanks for watching"},
+			{ID: 3, Start: 6, End: 8, Text: " Thanks for watching."},
+			{ID: 4, Start: 8, End: 9, Text: " Bye."},
+		},
+		Words: []openai.AudioWord{
+			{Word: "Thanks", Start: 0, End: 0.5},
+			{Word: "Thanks", Start: 2, End: 2.5},
+			{Word: "Bye", Start: 8, End: 8.5},
+		},
+	}
+
+	collapsed := looping.RemoveRepetitions(2)
+	expected := []openai.AudioSegment{
+		{ID: 0, Start: 0, End: 2, Text: " Thanks for watching."},
+		{ID: 1, Start: 8, End: 9, Text: " Bye."},
+	}
+	if !reflect.DeepEqual(collapsed.Segments, expected) {
+		t.Errorf("expected %v, got %v", expected, collapsed.Segments)
+	}
+	if collapsed.Text != "Thanks for watching. Bye." {
+		t.Errorf("unexpected text %q", collapsed.Text)
+	}
+	if len(collapsed.Words) != 2 || collapsed.Words[1].Word != "Bye" {
+		t.Errorf("expected the words of the removed segments to be removed, got %v", collapsed.Words)
+	}
+	if len(looping.Segments) != 5 {
+		t.Error("RemoveRepetitions modified the original response")
+	}
+
+	if again := looping.RemoveRepetitions(4); !reflect.DeepEqual(again, looping) {
+		t.Errorf("expected 4 repetitions to be kept with minRepeat 4, got %v", again.Segments)
+	}
+	clean := diarizedResponse()
+	if again := clean.RemoveRepetitions(1); !reflect.DeepEqual(again, clean) {
+		t.Errorf("expected a clean response to be unchanged, got %v", again.Segments)
+	}
+}
+
+func TestTextBetween(t *testing.T) {
+	res := diarizedResponse()
+	cases := []struct {
+		start, end time.Duration
+		expected   string
+	}{
+		{time.Second, 2 * time.Second, "Hello there. How are you?"},
+		{3100 * time.Millisecond, 3300 * time.Millisecond, "Fine, thanks."},
+		{10 * time.Second, 20 * time.Second, ""},
+		{2 * time.Second, time.Second, ""},
+	}
+	for _, c := range cases {
+		if got := res.TextBetween(c.start, c.end); got != c.expected {
+			t.Errorf("segments [%v, %v]: expected %q, got %q", c.start, c.end, c.expected, got)
+		}
+	}
+
+	res.Words = []openai.AudioWord{
+		{Word: "Hello", Start: 0, End: 0.6},
+		{Word: "there.", Start: 0.7, End: 1.4},
+		{Word: "How", Start: 1.5, End: 1.9},
+		{Word: "are", Start: 2, End: 2.3},
+		{Word: "you?", Start: 2.4, End: 3},
+	}
+	if got := res.TextBetween(time.Second/2, 2400*time.Millisecond); got != "there. How are" {
+		t.Errorf("expected boundary words to be trimmed, got %q", got)
+	}
+	if got := res.TextBetween(4*time.Second, 5*time.Second); got != "" {
+		t.Errorf("expected no words out of range, got %q", got)
+	}
+}
+
+func TestDominantSentiment(t *testing.T) {
+	testcases := []struct {
+		name      string
+		segments  []openai.AudioSegment
+		sentiment string
+		share     float64
+	}{
+		{"no segments", nil, "", 0},
+		{"no sentiment", []openai.AudioSegment{{Start: 0, End: 1}}, "", 0},
+		{
+			"weighted by duration",
+			[]openai.AudioSegment{
+				{Start: 0, End: 1, Sentiment: "happy"},
+				{Start: 1, End: 2, Sentiment: "happy"},
+				{Start: 2, End: 8, Sentiment: "neutral"},
+				{Start: 8, End: 9},
+			},
+			"neutral", 0.75,
+		},
+		{
+			"counted without timings",
+			[]openai.AudioSegment{{Sentiment: "sad"}, {Sentiment: "happy"}, {Sentiment: "sad"}, {Sentiment: "angry"}},
+			"sad", 0.5,
+		},
+		{"tie", []openai.AudioSegment{{Sentiment: "sad"}, {Sentiment: "happy"}}, "happy", 0.5},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sentiment, share := openai.AudioResponse{Segments: tc.segments}.DominantSentiment()
+			if sentiment != tc.sentiment || share != tc.share {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tc.sentiment, tc.share, sentiment, share)
+			}
+		})
+	}
+}
+
+func TestSpeechRate(t *testing.T) {
+	stats := diarizedResponse().SpeechRate()
+	if stats.Words != 8 || stats.VoicedDuration != 5300*time.Millisecond {
+		t.Fatalf("unexpected totals: %+v", stats)
+	}
+	if math.Abs(stats.WordsPerMinute-8/(5.3/60)) > 1e-9 {
+		t.Errorf("unexpected words per minute: %v", stats.WordsPerMinute)
+	}
+	spk0, spk1 := stats.Speakers["spk_0"], stats.Speakers["spk_1"]
+	if spk0.Words != 6 || spk0.VoicedDuration != 4500*time.Millisecond || spk0.WordsPerMinute != 80 {
+		t.Errorf("unexpected spk_0 stats: %+v", spk0)
+	}
+	if spk1.Words != 2 || spk1.VoicedDuration != 800*time.Millisecond || math.Abs(spk1.WordsPerMinute-150) > 1e-9 {
+		t.Errorf("unexpected spk_1 stats: %+v", spk1)
+	}
+
+	// Word timestamps take precedence over segment text; overlapping segments are voiced once.
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{Start: 0, End: 2, Text: "ignored text here"},
+			{Start: 1, End: 3, Text: "ignored"},
+		},
+		Words: []openai.AudioWord{
+			{Word: "one", Start: 0, End: 0.5},
+			{Word: "two", Start: 2.2, End: 2.8},
+			{Word: "three", Start: 5, End: 5.5},
+		},
+	}
+	stats = res.SpeechRate()
+	if stats.Words != 3 || stats.VoicedDuration != 3*time.Second || stats.WordsPerMinute != 60 || stats.Speakers != nil {
+		t.Errorf("unexpected stats from words: %+v", stats)
+	}
+
+	stats = openai.AudioResponse{Text: "just some text"}.SpeechRate()
+	if stats.Words != 3 || stats.VoicedDuration != 0 || stats.WordsPerMinute != 0 {
+		t.Errorf("unexpected stats from text: %+v", stats)
+	}
+}
+
+func TestMergeBySpeakerPreserveWhitespace(t *testing.T) {
+	res := openai.AudioResponse{
+		PreserveWhitespace: true,
+		Segments: []openai.AudioSegment{
+			{Start: 0, End: 1, Text: " Hello ", Speaker: "spk_0"},
+			{Start: 1, End: 2, Text: " world.", Speaker: "spk_0"},
+		},
+	}
+	if merged := res.MergeBySpeaker(); merged[0].Text != " Hello  world." {
+		t.Errorf("expected verbatim merged text, got %q", merged[0].Text)
+	}
+}
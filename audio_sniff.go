@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	ErrNotAudio = errors.New("input does not look like audio")
+)
+
+// audioSniffLen is the number of leading bytes needed to recognize every supported container.
+const audioSniffLen = 12
+
+// NotAudioError is returned when the first bytes of an upload do not match any known audio container.
+// It matches ErrNotAudio with errors.Is.
+type NotAudioError struct {
+	Header []byte // leading bytes of the input
+}
+
+func (e *NotAudioError) Error() string {
+	return fmt.Sprintf("%s: unrecognized header % x", ErrNotAudio, e.Header)
+}
+
+func (e *NotAudioError) Is(target error) bool {
+	return target == ErrNotAudio //nolint:errorlint // sentinel comparison
+}
+
+// SniffAudioHeader returns the audio container recognized from the magic bytes at the start of
+// header, e.g. "wav", "mp3", "ogg" or "mp4", or "" when the bytes do not look like audio.
+func SniffAudioHeader(header []byte) string {
+	has := func(offset int, magic string) bool {
+		return len(header) >= offset+len(magic) && string(header[offset:offset+len(magic)]) == magic
+	}
+	switch {
+	case has(0, "RIFF") && has(8, "WAVE"):
+		return "wav"
+	case has(0, "ID3"):
+		return "mp3"
+	case has(0, "OggS"):
+		return "ogg"
+	case has(0, "fLaC"):
+		return "flac"
+	case has(4, "ftyp"):
+		return "mp4"
+	case has(0, "\x1a\x45\xdf\xa3"):
+		return "webm"
+	case has(0, "FORM") && (has(8, "AIFF") || has(8, "AIFC")):
+		return "aiff"
+	case has(0, "#!AMR"):
+		return "amr"
+	case has(0, "caff"):
+		return "caf"
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xf6 == 0xf0:
+		return "aac"
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xe0 == 0xe0:
+		return "mp3"
+	}
+	return ""
+}
+
+// SniffAudioFormat peeks at the magic bytes of r and returns the recognized audio container, as
+// SniffAudioHeader does, together with a reader yielding the complete input. Seekable readers are
+// rewound and returned as is; other readers are wrapped so the peeked bytes are replayed. Unknown
+// formats are reported as "" without an error.
+func SniffAudioFormat(r io.Reader) (format string, wrapped io.Reader, err error) {
+	header, wrapped, err := peekAudioHeader(r)
+	if err != nil {
+		return "", nil, err
+	}
+	return SniffAudioHeader(header), wrapped, nil
+}
+
+// ValidateAudioReader checks that r starts with the magic bytes of an audio container and returns
+// a reader yielding the complete input, like SniffAudioFormat. A *NotAudioError is returned
+// otherwise.
+func ValidateAudioReader(r io.Reader) (io.Reader, error) {
+	header, restored, err := peekAudioHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if SniffAudioHeader(header) == "" {
+		return restored, &NotAudioError{Header: header}
+	}
+	return restored, nil
+}
+
+// peekAudioHeader reads the leading bytes of r and returns them with a reader yielding the
+// complete input.
+func peekAudioHeader(r io.Reader) ([]byte, io.Reader, error) {
+	header := make([]byte, audioSniffLen)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("reading audio header: %w", err)
+	}
+	header = header[:n]
+
+	if seeker, ok := r.(io.Seeker); ok {
+		if _, err = seeker.Seek(int64(-n), io.SeekCurrent); err != nil {
+			return nil, nil, fmt.Errorf("rewinding audio: %w", err)
+		}
+		return header, r, nil
+	}
+	return header, io.MultiReader(bytes.NewReader(header), r), nil
+}
+
+// validateAudioFile checks the magic bytes of f without moving its read offset.
+func validateAudioFile(f *os.File) error {
+	header := make([]byte, audioSniffLen)
+	n, err := f.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("reading audio header: %w", err)
+	}
+	if SniffAudioHeader(header[:n]) == "" {
+		return &NotAudioError{Header: header[:n]}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestValidateAudioReader(t *testing.T) {
+	wav := testWAV(8000, 1, make([]int16, 16))
+	if format := openai.SniffAudioHeader(wav); format != "wav" {
+		t.Fatalf("expected wav, got %q", format)
+	}
+
+	// A reader that cannot seek must still yield every byte after validation.
+	r, err := openai.ValidateAudioReader(io.MultiReader(bytes.NewReader(wav)))
+	checks.NoError(t, err, "ValidateAudioReader error")
+	got, _ := io.ReadAll(r)
+	if !bytes.Equal(got, wav) {
+		t.Fatalf("validated reader returned %d bytes, want %d", len(got), len(wav))
+	}
+
+	seekable := bytes.NewReader(wav)
+	r, err = openai.ValidateAudioReader(seekable)
+	checks.NoError(t, err, "ValidateAudioReader error")
+	if r != io.Reader(seekable) || seekable.Len() != len(wav) {
+		t.Fatal("expected the seekable reader to be rewound and returned as is")
+	}
+
+	_, err = openai.ValidateAudioReader(strings.NewReader("just some notes, not audio"))
+	checks.ErrorIs(t, err, openai.ErrNotAudio, "text should not validate as audio")
+	var notAudio *openai.NotAudioError
+	if !errors.As(err, &notAudio) || string(notAudio.Header) != "just some no" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSniffAudioFormat(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   []byte
+		format string
+	}{
+		{"wav", testWAV(8000, 1, make([]int16, 16)), "wav"},
+		{"mp3 with ID3 tag", []byte("ID3\x04\x00\x00\x00\x00\x00\x00frames"), "mp3"},
+		{"ogg", []byte("OggS\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00opus"), "ogg"},
+		{"unknown", []byte("just some notes, not audio"), ""},
+		{"short", []byte("Og"), ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			format, r, err := openai.SniffAudioFormat(io.MultiReader(bytes.NewReader(c.data)))
+			checks.NoError(t, err, "SniffAudioFormat error")
+			if format != c.format {
+				t.Errorf("expected format %q, got %q", c.format, format)
+			}
+			got, _ := io.ReadAll(r)
+			if !bytes.Equal(got, c.data) {
+				t.Errorf("expected the consumed bytes to be replayed, got %q", got)
+			}
+		})
+	}
+}
+
+func TestCreateTranscriptionValidateAudio(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.mp3")
+	checks.NoError(t, os.WriteFile(notes, []byte("meeting notes\n"), 0o600), "WriteFile error")
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:         openai.Whisper1,
+		FilePath:      notes,
+		ValidateAudio: true,
+	})
+	checks.ErrorIs(t, err, openai.ErrNotAudio, "a text file should be rejected")
+	if calls != 0 {
+		t.Fatalf("expected no upload, got %d calls", calls)
+	}
+
+	audio := filepath.Join(dir, "audio.wav")
+	checks.NoError(t, os.WriteFile(audio, testWAV(8000, 1, make([]int16, 16)), 0o600), "WriteFile error")
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:         openai.Whisper1,
+		FilePath:      audio,
+		ValidateAudio: true,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if calls != 1 {
+		t.Fatalf("expected one upload, got %d calls", calls)
+	}
+}
@@ -0,0 +1,175 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrTranscriptionStreamNotSupported = errors.New("streaming is not supported with this method, please use CreateTranscriptionStream") //nolint:lll
+)
+
+type TranscriptionStreamEventType string
+
+const (
+	TranscriptionStreamEventTypeDelta TranscriptionStreamEventType = "transcript.text.delta"
+	TranscriptionStreamEventTypeDone  TranscriptionStreamEventType = "transcript.text.done"
+	// TranscriptionStreamEventTypeSegment carries a timed segment (SenseASR extension). Segments
+	// marked Transient are interim hypotheses that will be replaced by a final segment.
+	TranscriptionStreamEventTypeSegment TranscriptionStreamEventType = "transcript.text.segment"
+)
+
+// TranscriptionTextDelta is the payload of a transcript.text.delta event.
+type TranscriptionTextDelta struct {
+	Delta string `json:"delta"`
+}
+
+// TranscriptionTextDone is the payload of a transcript.text.done event.
+type TranscriptionTextDone struct {
+	Text string `json:"text"`
+}
+
+// TranscriptionTextSegment is the payload of a transcript.text.segment event.
+type TranscriptionTextSegment struct {
+	Segment AudioSegment `json:"segment"`
+}
+
+// TranscriptionStreamEvent is a single server-sent event of a streaming transcription.
+// Type tells which payload is set; events of a type unknown to this package carry only Raw.
+type TranscriptionStreamEvent struct {
+	Type    TranscriptionStreamEventType
+	Delta   *TranscriptionTextDelta
+	Done    *TranscriptionTextDone
+	Segment *TranscriptionTextSegment
+
+	// Raw is the event data as received, set for every event.
+	Raw json.RawMessage
+}
+
+func (e *TranscriptionStreamEvent) UnmarshalJSON(data []byte) error {
+	var header struct {
+		Type TranscriptionStreamEventType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	*e = TranscriptionStreamEvent{
+		Type: header.Type,
+		Raw:  append(json.RawMessage(nil), data...),
+	}
+	switch e.Type {
+	case TranscriptionStreamEventTypeDelta:
+		e.Delta = &TranscriptionTextDelta{}
+		return json.Unmarshal(data, e.Delta)
+	case TranscriptionStreamEventTypeDone:
+		e.Done = &TranscriptionTextDone{}
+		return json.Unmarshal(data, e.Done)
+	case TranscriptionStreamEventTypeSegment:
+		e.Segment = &TranscriptionTextSegment{}
+		return json.Unmarshal(data, e.Segment)
+	default:
+		return nil
+	}
+}
+
+// TranscriptionStream reads the server-sent events of a streaming transcription.
+//
+// Callers must always Close the stream, even after Recv returned io.EOF,
+// to release the underlying connection.
+type TranscriptionStream struct {
+	*streamReader[TranscriptionStreamEvent]
+
+	closed bool
+	offset float64 // request.StartOffset, added to segment timings
+}
+
+// CreateTranscriptionStream — API call to create a transcription with streaming support.
+// The transcribed text is sent back as text deltas while the audio is being processed.
+// StartOffset and EndOffset are honored as in CreateTranscription: segment events carry
+// timings relative to the original recording.
+func (c *Client) CreateTranscriptionStream(
+	ctx context.Context,
+	request AudioRequest,
+) (stream *TranscriptionStream, err error) {
+	request.Stream = true
+	if request, err = c.prepareAudioRequest(request); err != nil {
+		return nil, err
+	}
+	offset := request.StartOffset
+	if request, err = transformAudioInput(request); err != nil {
+		return nil, err
+	}
+
+	var formBody bytes.Buffer
+	builder := c.createFormBuilder(&formBody)
+	if err = audioMultipartForm(request, builder); err != nil {
+		return nil, err
+	}
+	if err = c.checkUploadSize(formBody.Len()); err != nil {
+		return nil, err
+	}
+
+	urlSuffix := "/audio/transcriptions"
+	req, err := c.newRequest(
+		ctx,
+		http.MethodPost,
+		c.fullURL(urlSuffix, withModel(request.Model)),
+		withBody(&formBody),
+		withContentType(builder.FormDataContentType()),
+		withAudioEndpoint(urlSuffix),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sendRequestStream[TranscriptionStreamEvent](c, req)
+	if err != nil {
+		return
+	}
+	stream = &TranscriptionStream{
+		streamReader: resp,
+		offset:       offset.Seconds(),
+	}
+	return
+}
+
+// Recv returns the next event of the stream. After the transcript.text.done event
+// has been returned, or once the server closed the stream, Recv returns io.EOF.
+func (s *TranscriptionStream) Recv() (event TranscriptionStreamEvent, err error) {
+	if s.closed {
+		return event, io.EOF
+	}
+	event, err = s.streamReader.Recv()
+	if err != nil {
+		return event, err
+	}
+	switch event.Type {
+	case TranscriptionStreamEventTypeDelta:
+	case TranscriptionStreamEventTypeDone:
+		s.isFinished = true
+	case TranscriptionStreamEventTypeSegment:
+		if event.Segment != nil {
+			event.Segment.Segment.Start += s.offset
+			event.Segment.Segment.End += s.offset
+		}
+	}
+	return event, nil
+}
+
+// Close stops reading the stream and releases the connection. It is safe to call
+// Close more than once and before Recv returned io.EOF.
+func (s *TranscriptionStream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.streamReader == nil || s.response == nil {
+		return nil
+	}
+	return s.streamReader.Close()
+}
@@ -0,0 +1,112 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// StreamBackpressure tells what happens to an event when the buffer of a
+// TranscriptionEventChannel is full.
+type StreamBackpressure int
+
+const (
+	// StreamBackpressureBlock waits for a consumer to make room, slowing down the stream.
+	StreamBackpressureBlock StreamBackpressure = iota
+	// StreamBackpressureDrop discards the event, except for the final transcript.text.done event.
+	StreamBackpressureDrop
+)
+
+// TranscriptionChannelOptions configures CreateTranscriptionStreamChannel.
+type TranscriptionChannelOptions struct {
+	// BufferSize is the capacity of the Events channel. Defaults to 16.
+	BufferSize int
+
+	// Backpressure applies when the buffer is full. Defaults to StreamBackpressureBlock.
+	Backpressure StreamBackpressure
+}
+
+const defaultTranscriptionChannelBuffer = 16
+
+// TranscriptionEventChannel publishes the events of a streaming transcription on a channel, so
+// that they can be consumed by several goroutines.
+type TranscriptionEventChannel struct {
+	// Events receives the events in order. It is closed when the stream ends, fails, or ctx is done.
+	Events <-chan TranscriptionStreamEvent
+
+	done    chan struct{}
+	err     error
+	dropped int64
+}
+
+// Err waits until Events is closed and returns the error that ended the stream, or nil when the
+// stream completed. Consumers must keep draining Events, or cancel ctx, for Err to return.
+func (c *TranscriptionEventChannel) Err() error {
+	<-c.done
+	return c.err
+}
+
+// Dropped returns the number of events discarded so far because of StreamBackpressureDrop.
+func (c *TranscriptionEventChannel) Dropped() int {
+	return int(atomic.LoadInt64(&c.dropped))
+}
+
+// CreateTranscriptionStreamChannel — starts a streaming transcription like CreateTranscriptionStream
+// and publishes its events on a buffered channel from a background goroutine. Errors starting the
+// stream are returned directly; later ones are reported by Err once Events is closed. The stream
+// is closed when publishing stops.
+func (c *Client) CreateTranscriptionStreamChannel(
+	ctx context.Context,
+	request AudioRequest,
+	options TranscriptionChannelOptions,
+) (*TranscriptionEventChannel, error) {
+	stream, err := c.CreateTranscriptionStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	size := options.BufferSize
+	if size <= 0 {
+		size = defaultTranscriptionChannelBuffer
+	}
+	events := make(chan TranscriptionStreamEvent, size)
+	channel := &TranscriptionEventChannel{Events: events, done: make(chan struct{})}
+	go func() {
+		defer close(channel.done)
+		defer close(events)
+		defer stream.Close()
+		channel.err = channel.publish(ctx, stream, events, options.Backpressure)
+	}()
+	return channel, nil
+}
+
+func (c *TranscriptionEventChannel) publish(
+	ctx context.Context,
+	stream *TranscriptionStream,
+	events chan<- TranscriptionStreamEvent,
+	backpressure StreamBackpressure,
+) error {
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if backpressure == StreamBackpressureDrop && event.Type != TranscriptionStreamEventTypeDone {
+			select {
+			case events <- event:
+			default:
+				atomic.AddInt64(&c.dropped, 1)
+			}
+			continue
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
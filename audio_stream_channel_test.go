@@ -0,0 +1,123 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func setupTranscriptionChannelServer(t *testing.T, deltas int) *openai.Client {
+	t.Helper()
+	client, server, teardown := setupOpenAITestServer()
+	t.Cleanup(teardown)
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		events := make([]string, 0, deltas+1)
+		for i := 0; i < deltas; i++ {
+			events = append(events, fmt.Sprintf(`{"type":"transcript.text.delta","delta":"%d"}`, i))
+		}
+		events = append(events, `{"type":"transcript.text.done","text":"all"}`)
+		writeTranscriptionEvents(w, events...)
+	})
+	return client
+}
+
+func TestCreateTranscriptionStreamChannel(t *testing.T) {
+	client := setupTranscriptionChannelServer(t, 20)
+
+	channel, err := client.CreateTranscriptionStreamChannel(context.Background(), openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.wav",
+		Reader:   strings.NewReader("audio"),
+	}, openai.TranscriptionChannelOptions{BufferSize: 2})
+	checks.NoError(t, err, "CreateTranscriptionStreamChannel error")
+
+	var (
+		mu       sync.Mutex
+		received []string
+		wg       sync.WaitGroup
+	)
+	for consumer := 0; consumer < 3; consumer++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range channel.Events {
+				mu.Lock()
+				if event.Delta != nil {
+					received = append(received, event.Delta.Delta)
+				} else if event.Done != nil {
+					received = append(received, "done:"+event.Done.Text)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	checks.NoError(t, channel.Err(), "stream error")
+
+	if len(received) != 21 {
+		t.Fatalf("expected 21 events, got %d: %v", len(received), received)
+	}
+	sort.Strings(received)
+	if received[len(received)-1] != "done:all" {
+		t.Errorf("expected the done event, got %v", received)
+	}
+	if channel.Dropped() != 0 {
+		t.Errorf("expected no dropped events when blocking, got %d", channel.Dropped())
+	}
+}
+
+func TestCreateTranscriptionStreamChannelDrop(t *testing.T) {
+	client := setupTranscriptionChannelServer(t, 5)
+
+	channel, err := client.CreateTranscriptionStreamChannel(context.Background(), openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.wav",
+		Reader:   strings.NewReader("audio"),
+	}, openai.TranscriptionChannelOptions{BufferSize: 1, Backpressure: openai.StreamBackpressureDrop})
+	checks.NoError(t, err, "CreateTranscriptionStreamChannel error")
+
+	// Nobody reads until the publisher has dropped every delta that did not fit the buffer.
+	for deadline := time.Now().Add(5 * time.Second); channel.Dropped() < 4 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+
+	var types []openai.TranscriptionStreamEventType
+	for event := range channel.Events {
+		types = append(types, event.Type)
+	}
+	checks.NoError(t, channel.Err(), "stream error")
+	if channel.Dropped() != 4 {
+		t.Errorf("expected 4 dropped deltas, got %d", channel.Dropped())
+	}
+	expected := []openai.TranscriptionStreamEventType{
+		openai.TranscriptionStreamEventTypeDelta,
+		openai.TranscriptionStreamEventTypeDone,
+	}
+	if len(types) != len(expected) || types[0] != expected[0] || types[1] != expected[1] {
+		t.Errorf("expected the first delta and the done event, got %v", types)
+	}
+}
+
+func TestCreateTranscriptionStreamChannelCancel(t *testing.T) {
+	client := setupTranscriptionChannelServer(t, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	channel, err := client.CreateTranscriptionStreamChannel(ctx, openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.wav",
+		Reader:   strings.NewReader("audio"),
+	}, openai.TranscriptionChannelOptions{BufferSize: 1})
+	checks.NoError(t, err, "CreateTranscriptionStreamChannel error")
+
+	<-channel.Events
+	cancel()
+	checks.ErrorIs(t, channel.Err(), context.Canceled, "expected the cancellation to be reported")
+}
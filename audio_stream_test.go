@@ -0,0 +1,173 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func writeTranscriptionEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, event := range events {
+		_, _ = w.Write([]byte("data: " + event + "\n\n"))
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func TestCreateTranscriptionStream(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/form-data" {
+			http.Error(w, "request is not multipart", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("stream") != "true" {
+			http.Error(w, "stream not requested", http.StatusBadRequest)
+			return
+		}
+		writeTranscriptionEvents(w,
+			`{"type":"transcript.text.delta","delta":"Hello"}`,
+			`{"type":"transcript.text.logprobs","logprobs":[{"token":"Hello","logprob":-0.1}]}`,
+			`{"type":"transcript.text.delta","delta":" world"}`,
+			`{"type":"transcript.text.done","text":"Hello world"}`,
+		)
+	})
+
+	stream, err := client.CreateTranscriptionStream(context.Background(), openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+	})
+	checks.NoErrorF(t, err, "CreateTranscriptionStream error")
+	defer stream.Close()
+
+	var (
+		text    strings.Builder
+		done    string
+		unknown []openai.TranscriptionStreamEvent
+	)
+	for {
+		event, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoErrorF(t, recvErr, "Recv error")
+		switch event.Type {
+		case openai.TranscriptionStreamEventTypeDelta:
+			text.WriteString(event.Delta.Delta)
+		case openai.TranscriptionStreamEventTypeDone:
+			done = event.Done.Text
+		default:
+			unknown = append(unknown, event)
+		}
+	}
+	if text.String() != "Hello world" || done != "Hello world" {
+		t.Errorf("unexpected transcript %q / %q", text.String(), done)
+	}
+	if len(unknown) != 1 || unknown[0].Type != "transcript.text.logprobs" ||
+		unknown[0].Delta != nil || !strings.Contains(string(unknown[0].Raw), `"logprob":-0.1`) {
+		t.Errorf("unknown event not surfaced: %+v", unknown)
+	}
+
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF, "Recv after done should keep returning io.EOF")
+	checks.NoError(t, stream.Close(), "Close after EOF error")
+	checks.NoError(t, stream.Close(), "second Close error")
+}
+
+func TestTranscriptionStreamCloseBeforeEOF(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	released := make(chan struct{})
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		writeTranscriptionEvents(w, `{"type":"transcript.text.delta","delta":"Hello"}`)
+		<-r.Context().Done()
+		close(released)
+	})
+
+	stream, err := client.CreateTranscriptionStream(context.Background(), openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+	})
+	checks.NoErrorF(t, err, "CreateTranscriptionStream error")
+
+	event, err := stream.Recv()
+	checks.NoErrorF(t, err, "Recv error")
+	if event.Delta == nil || event.Delta.Delta != "Hello" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	checks.NoError(t, stream.Close(), "Close error")
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("closing the stream did not release the connection")
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF, "Recv after Close should return io.EOF")
+}
+
+func TestCreateTranscriptionWithStreamSet(t *testing.T) {
+	client := openai.NewClient("test-token")
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+		Stream:   true,
+	})
+	checks.ErrorIs(t, err, openai.ErrTranscriptionStreamNotSupported, "unexpected error")
+}
+
+func TestCreateTranscriptionStreamOffsets(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var uploaded int
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		uploaded = len(data)
+		writeTranscriptionEvents(w,
+			`{"type":"transcript.text.segment","segment":{"id":0,"start":0.1,"end":0.9,"text":" clip"}}`,
+			`{"type":"transcript.text.done","text":"clip"}`,
+		)
+	})
+
+	stream, err := client.CreateTranscriptionStream(context.Background(), openai.AudioRequest{
+		Model:       "gpt-4o-transcribe",
+		FilePath:    "long.wav",
+		Reader:      bytes.NewReader(silentWAV(3 * time.Second)),
+		StartOffset: time.Second,
+		EndOffset:   2 * time.Second,
+	})
+	checks.NoError(t, err, "CreateTranscriptionStream error")
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if want := 44 + 8000*2; uploaded != want {
+		t.Errorf("expected a one second clip of %d bytes to be uploaded, got %d", want, uploaded)
+	}
+	if segment := event.Segment.Segment; segment.Start != 1.1 || segment.End != 1.9 {
+		t.Errorf("segment timings not shifted by the start offset: %+v", segment)
+	}
+}
@@ -0,0 +1,138 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTranscriptionStreamNoSegments = errors.New("transcription stream ended without segment events")
+)
+
+// forEachFinalSegment calls fn for every final (non transient) segment received from stream,
+// until the stream ends. io.EOF is not reported as an error.
+func forEachFinalSegment(stream *TranscriptionStream, fn func(AudioSegment) error) error {
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if event.Segment == nil || event.Segment.Segment.Transient {
+			continue
+		}
+		if err = fn(event.Segment.Segment); err != nil {
+			return err
+		}
+	}
+}
+
+// flushWriter flushes w when it supports it, e.g. an http.ResponseWriter or a *bufio.Writer.
+func flushWriter(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
+
+// formatSubtitleTimestamp formats seconds as HH:MM:SS followed by sep and milliseconds.
+func formatSubtitleTimestamp(seconds float64, sep string) string {
+	ms := int64(math.Round(math.Max(seconds, 0) * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// writeSubtitleCue writes a single SubRip cue, or WebVTT cue with "." as sep.
+func writeSubtitleCue(w io.Writer, number int, segment AudioSegment, sep string) error {
+	_, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+		number,
+		formatSubtitleTimestamp(segment.Start, sep),
+		formatSubtitleTimestamp(segment.End, sep),
+		strings.TrimSpace(segment.Text),
+	)
+	return err
+}
+
+// StreamToSRT writes every final segment received from stream to w as a SubRip cue as soon as it
+// is complete, flushing w after each cue when it supports flushing. Cues are numbered from 1.
+// It returns when the stream is done; the caller still owns and must Close the stream.
+//
+// Cue timings come from transcript.text.segment events, a SenseASR extension. Standard OpenAI
+// streams only carry text deltas without timings: for them nothing is written and
+// ErrTranscriptionStreamNoSegments is returned.
+func StreamToSRT(stream *TranscriptionStream, w io.Writer) error {
+	number := 0
+	err := forEachFinalSegment(stream, func(segment AudioSegment) error {
+		number++
+		if err := writeSubtitleCue(w, number, segment, ","); err != nil {
+			return err
+		}
+		return flushWriter(w)
+	})
+	if err == nil && number == 0 {
+		return ErrTranscriptionStreamNoSegments
+	}
+	return err
+}
+
+// StreamToVTTWriter writes every final segment received from stream to w as a WebVTT cue as soon
+// as it is complete, e.g. to relay live captions to a browser. The WEBVTT header is written and
+// flushed before the first event is received; each cue is then flushed like in StreamToSRT and
+// numbered from 1. It returns when the stream is done; the caller still owns and must Close the
+// stream. Like StreamToSRT, it returns ErrTranscriptionStreamNoSegments, after writing only the
+// header, for streams without segment events.
+func StreamToVTTWriter(stream *TranscriptionStream, w io.Writer) error {
+	if _, err := io.WriteString(w, "WEBVTT\n\n"); err != nil {
+		return err
+	}
+	if err := flushWriter(w); err != nil {
+		return err
+	}
+	number := 0
+	err := forEachFinalSegment(stream, func(segment AudioSegment) error {
+		number++
+		if err := writeSubtitleCue(w, number, segment, "."); err != nil {
+			return err
+		}
+		return flushWriter(w)
+	})
+	if err == nil && number == 0 {
+		return ErrTranscriptionStreamNoSegments
+	}
+	return err
+}
+
+// DefaultTimestampLayout is the layout used by StreamToTimestampedText when none is given.
+const DefaultTimestampLayout = "15:04:05"
+
+// StreamToTimestampedText writes every final segment received from stream to w as soon as it is
+// complete, as a "[start] text" line, flushing w after each line when it supports flushing.
+// layout is a time.Format layout applied to the start offset of the segment, e.g. "04:05" for
+// "[00:12] text"; it defaults to DefaultTimestampLayout. Offsets wrap after 24 hours.
+// Like StreamToSRT, it returns ErrTranscriptionStreamNoSegments for streams without segment events.
+func StreamToTimestampedText(stream *TranscriptionStream, w io.Writer, layout string) error {
+	if layout == "" {
+		layout = DefaultTimestampLayout
+	}
+	lines := 0
+	err := forEachFinalSegment(stream, func(segment AudioSegment) error {
+		lines++
+		start := time.Time{}.Add(secondsToDuration(math.Max(segment.Start, 0)))
+		if _, err := fmt.Fprintf(w, "[%s] %s\n", start.Format(layout), strings.TrimSpace(segment.Text)); err != nil {
+			return err
+		}
+		return flushWriter(w)
+	})
+	if err == nil && lines == 0 {
+		return ErrTranscriptionStreamNoSegments
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSRT parses SubRip subtitles, e.g. the Text of a transcription requested with
+// AudioResponseFormatSRT, into a response with one segment per cue. Parsing is lenient: a
+// malformed cue is skipped, the others are kept, and a warning naming the line of the skipped cue
+// is added to Warnings.
+func ParseSRT(data string) AudioResponse {
+	return parseSubtitles(data, false)
+}
+
+// ParseVTT parses WebVTT subtitles like ParseSRT. The WEBVTT header and NOTE, STYLE and REGION
+// blocks are ignored, as are cue settings.
+func ParseVTT(data string) AudioResponse {
+	return parseSubtitles(data, true)
+}
+
+// subtitleBlock is a group of non-empty lines, starting at line number line (1-based).
+type subtitleBlock struct {
+	line  int
+	lines []string
+}
+
+func parseSubtitles(data string, vtt bool) AudioResponse {
+	var response AudioResponse
+	for i, block := range subtitleBlocks(data) {
+		if vtt && isVTTMetadataBlock(block, i == 0) {
+			continue
+		}
+		segment, err := parseSubtitleCue(block)
+		if err != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("skipped cue at line %d: %v", block.line, err))
+			continue
+		}
+		segment.ID = len(response.Segments)
+		response.Segments = append(response.Segments, segment)
+	}
+	if len(response.Segments) > 0 {
+		response.Text = response.segmentsText(response.Segments)
+		response.Duration = response.Segments[len(response.Segments)-1].End
+	}
+	return response
+}
+
+// subtitleBlocks splits data into blocks separated by blank lines.
+func subtitleBlocks(data string) []subtitleBlock {
+	data = strings.TrimPrefix(data, "\ufeff") // byte order mark
+	var blocks []subtitleBlock
+	var current *subtitleBlock
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, subtitleBlock{line: i + 1})
+			current = &blocks[len(blocks)-1]
+		}
+		current.lines = append(current.lines, line)
+	}
+	return blocks
+}
+
+// isVTTMetadataBlock reports whether a WebVTT block is the file header or a non-cue block.
+func isVTTMetadataBlock(block subtitleBlock, first bool) bool {
+	head := block.lines[0]
+	if first && strings.HasPrefix(head, "WEBVTT") {
+		return true
+	}
+	for _, keyword := range []string{"NOTE", "STYLE", "REGION"} {
+		if head == keyword || strings.HasPrefix(head, keyword+" ") || strings.HasPrefix(head, keyword+"\t") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSubtitleCue parses a cue made of an optional identifier, a timing line and text lines.
+func parseSubtitleCue(block subtitleBlock) (AudioSegment, error) {
+	lines := block.lines
+	if !strings.Contains(lines[0], "-->") {
+		lines = lines[1:] // cue number or identifier
+	}
+	if len(lines) == 0 || !strings.Contains(lines[0], "-->") {
+		return AudioSegment{}, errors.New("missing timing line")
+	}
+	startText, rest, _ := strings.Cut(lines[0], "-->")
+	endFields := strings.Fields(rest)
+	if len(endFields) == 0 {
+		return AudioSegment{}, errors.New("missing end time")
+	}
+	start, err := parseSubtitleTimestamp(strings.TrimSpace(startText))
+	if err != nil {
+		return AudioSegment{}, err
+	}
+	end, err := parseSubtitleTimestamp(endFields[0]) // anything after the end time are cue settings
+	if err != nil {
+		return AudioSegment{}, err
+	}
+	if end < start {
+		return AudioSegment{}, fmt.Errorf("end %s is before start %s", endFields[0], strings.TrimSpace(startText))
+	}
+
+	text := make([]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		text = append(text, strings.TrimSpace(line))
+	}
+	return AudioSegment{Start: start, End: end, Text: " " + strings.Join(text, " ")}, nil
+}
+
+const (
+	secondsPerMinute = 60
+	minutesPerHour   = 60
+)
+
+// parseSubtitleTimestamp parses HH:MM:SS,mmm (SubRip) or [HH:]MM:SS.mmm (WebVTT) into seconds.
+func parseSubtitleTimestamp(value string) (float64, error) {
+	invalid := fmt.Errorf("invalid timestamp %q", value)
+	hoursText, rest := "0", strings.Replace(value, ",", ".", 1)
+	if strings.Count(rest, ":") > 1 {
+		hoursText, rest, _ = strings.Cut(rest, ":")
+	}
+	minutesText, secondsText, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, invalid
+	}
+	hours, err := strconv.Atoi(hoursText)
+	if err != nil || hours < 0 {
+		return 0, invalid
+	}
+	minutes, err := strconv.Atoi(minutesText)
+	if err != nil || minutes < 0 || minutes >= minutesPerHour {
+		return 0, invalid
+	}
+	seconds, err := strconv.ParseFloat(secondsText, 64)
+	if err != nil || seconds < 0 || seconds >= secondsPerMinute {
+		return 0, invalid
+	}
+	return float64((hours*minutesPerHour+minutes)*secondsPerMinute) + seconds, nil
+}
@@ -0,0 +1,54 @@
+package openai_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestParseSRT(t *testing.T) {
+	const srt = "1\r\n00:00:00,000 --> 00:00:01,500\r\nHello there.\r\n\r\n" +
+		"2\r\n00:00:01,500 -> 00:00:03,000\r\nBroken arrow.\r\n\r\n" +
+		"3\r\n00:00:03,000 --> 00:00:04,250\r\nHow are\r\nyou?\r\n\r\n" +
+		"4\r\n00:00:05,000 --> 00:00:04,000\r\nBackwards.\r\n\r\n" +
+		"5\r\n00:00:06,000 --> 00:00:07,000\r\nBye.\r\n"
+
+	res := openai.ParseSRT(srt)
+	expected := []openai.AudioSegment{
+		{ID: 0, Start: 0, End: 1.5, Text: " Hello there."},
+		{ID: 1, Start: 3, End: 4.25, Text: " How are you?"},
+		{ID: 2, Start: 6, End: 7, Text: " Bye."},
+	}
+	if !reflect.DeepEqual(res.Segments, expected) {
+		t.Fatalf("expected %v, got %v", expected, res.Segments)
+	}
+	if res.Text != "Hello there. How are you? Bye." || res.Duration != 7 {
+		t.Errorf("unexpected text %q or duration %v", res.Text, res.Duration)
+	}
+	if len(res.Warnings) != 2 ||
+		!strings.HasPrefix(res.Warnings[0], "skipped cue at line 5:") ||
+		!strings.HasPrefix(res.Warnings[1], "skipped cue at line 14:") {
+		t.Errorf("expected warnings naming lines 5 and 14, got %q", res.Warnings)
+	}
+}
+
+func TestParseVTT(t *testing.T) {
+	const vtt = "WEBVTT\n\nNOTE generated by whisper\n\n" +
+		"00:01.000 --> 00:02.000 align:start\nFirst cue.\n\n" +
+		"intro\n01:00:02.000 --> 01:00:03.500\nSecond cue.\n\n" +
+		"00:61.000 --> 00:62.000\nBad seconds.\n"
+
+	res := openai.ParseVTT(vtt)
+	expected := []openai.AudioSegment{
+		{ID: 0, Start: 1, End: 2, Text: " First cue."},
+		{ID: 1, Start: 3602, End: 3603.5, Text: " Second cue."},
+	}
+	if !reflect.DeepEqual(res.Segments, expected) {
+		t.Fatalf("expected %v, got %v", expected, res.Segments)
+	}
+	if len(res.Warnings) != 1 || !strings.HasPrefix(res.Warnings[0], "skipped cue at line 12: invalid timestamp") {
+		t.Errorf("expected a warning naming line 12, got %q", res.Warnings)
+	}
+}
@@ -0,0 +1,116 @@
+package openai_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// scriptedSegmentEvents is a stream with interim, final and unrelated events.
+var scriptedSegmentEvents = []string{
+	`{"type":"transcript.text.delta","delta":"Hello"}`,
+	`{"type":"transcript.text.segment","segment":{"id":0,"start":0,"end":1.2,"text":" Hel","transient":true}}`,
+	`{"type":"transcript.text.segment","segment":{"id":0,"start":0,"end":1.5,"text":" Hello there."}}`,
+	`{"type":"transcript.text.delta","delta":" General"}`,
+	`{"type":"transcript.text.segment","segment":{"id":1,"start":1.5,"end":3723.25,"text":" General Kenobi!"}}`,
+	`{"type":"transcript.text.done","text":"Hello there. General Kenobi!"}`,
+	`{"type":"transcript.text.segment","segment":{"id":2,"start":4000,"end":4001,"text":" after done"}}`,
+}
+
+func newScriptedTranscriptionStream(t *testing.T, events ...string) *openai.TranscriptionStream {
+	t.Helper()
+	client, server, teardown := setupOpenAITestServer()
+	t.Cleanup(teardown)
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, _ *http.Request) {
+		writeTranscriptionEvents(w, events...)
+	})
+
+	stream, err := client.CreateTranscriptionStream(context.Background(), openai.AudioRequest{
+		Model:    "gpt-4o-transcribe",
+		FilePath: "audio.wav",
+		Reader:   strings.NewReader("audio"),
+	})
+	checks.NoErrorF(t, err, "CreateTranscriptionStream error")
+	t.Cleanup(func() { stream.Close() })
+	return stream
+}
+
+func TestStreamToSRT(t *testing.T) {
+	stream := newScriptedTranscriptionStream(t, scriptedSegmentEvents...)
+
+	var out bytes.Buffer
+	w := bufio.NewWriter(&out)
+	err := openai.StreamToSRT(stream, w)
+	checks.NoErrorF(t, err, "StreamToSRT error")
+
+	const expected = "1\n00:00:00,000 --> 00:00:01,500\nHello there.\n\n" +
+		"2\n00:00:01,500 --> 01:02:03,250\nGeneral Kenobi!\n\n"
+	if out.String() != expected {
+		t.Errorf("unexpected SRT output:\n%q\nexpected:\n%q", out.String(), expected)
+	}
+}
+
+func TestStreamToVTTWriter(t *testing.T) {
+	stream := newScriptedTranscriptionStream(t, scriptedSegmentEvents...)
+	recorder := httptest.NewRecorder()
+	checks.NoErrorF(t, openai.StreamToVTTWriter(stream, recorder), "StreamToVTTWriter error")
+
+	const expected = "WEBVTT\n\n" +
+		"1\n00:00:00.000 --> 00:00:01.500\nHello there.\n\n" +
+		"2\n00:00:01.500 --> 01:02:03.250\nGeneral Kenobi!\n\n"
+	if got := recorder.Body.String(); got != expected {
+		t.Errorf("unexpected WebVTT output:\n%q\nexpected:\n%q", got, expected)
+	}
+	if !recorder.Flushed {
+		t.Error("expected the cues to be flushed")
+	}
+	if parsed := openai.ParseVTT(expected); len(parsed.Segments) != 2 {
+		t.Errorf("expected the output to parse back to 2 cues, got %d", len(parsed.Segments))
+	}
+
+	stream = newScriptedTranscriptionStream(t, `{"type":"transcript.text.done","text":"Hello"}`)
+	var out bytes.Buffer
+	err := openai.StreamToVTTWriter(stream, &out)
+	checks.ErrorIs(t, err, openai.ErrTranscriptionStreamNoSegments, "expected an error for a stream without timings")
+	if out.String() != "WEBVTT\n\n" {
+		t.Errorf("expected only the header, got %q", out.String())
+	}
+}
+
+func TestStreamToTimestampedText(t *testing.T) {
+	stream := newScriptedTranscriptionStream(t, scriptedSegmentEvents...)
+	var out bytes.Buffer
+	w := bufio.NewWriter(&out)
+	checks.NoErrorF(t, openai.StreamToTimestampedText(stream, w, "04:05"), "StreamToTimestampedText error")
+	if expected := "[00:00] Hello there.\n[00:01] General Kenobi!\n"; out.String() != expected {
+		t.Errorf("unexpected output %q, expected %q", out.String(), expected)
+	}
+
+	stream = newScriptedTranscriptionStream(t, scriptedSegmentEvents...)
+	out.Reset()
+	checks.NoErrorF(t, openai.StreamToTimestampedText(stream, &out, ""), "StreamToTimestampedText error")
+	if expected := "[00:00:00] Hello there.\n[00:00:01] General Kenobi!\n"; out.String() != expected {
+		t.Errorf("unexpected output with the default layout %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestStreamToSRTWithoutSegments(t *testing.T) {
+	stream := newScriptedTranscriptionStream(t,
+		`{"type":"transcript.text.delta","delta":"Hello"}`,
+		`{"type":"transcript.text.done","text":"Hello"}`,
+	)
+
+	var out bytes.Buffer
+	err := openai.StreamToSRT(stream, &out)
+	checks.ErrorIs(t, err, openai.ErrTranscriptionStreamNoSegments, "expected an error for a stream without timings")
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+}
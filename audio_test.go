@@ -239,3 +239,47 @@ func TestCallAudioAPISendRequestErrorText(t *testing.T) {
 		t.Errorf("expected error %v, got %v", errHTTP, err)
 	}
 }
+
+func TestAudioMultipartBodyWithFixedBoundary(t *testing.T) {
+	server := test.NewTestServer()
+	var contentType, body string
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	client.createFormBuilder = func(body io.Writer) utils.FormBuilder {
+		builder := utils.NewFormBuilder(body)
+		_ = builder.SetBoundary("golden")
+		return builder
+	}
+
+	_, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:    Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   bytes.NewReader([]byte("ID3")),
+		Language: "en",
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+
+	if contentType != "multipart/form-data; boundary=golden" {
+		t.Errorf("unexpected Content-Type %q", contentType)
+	}
+	const golden = "--golden\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"audio.mp3\"\r\n\r\n" +
+		"ID3\r\n" +
+		"--golden\r\nContent-Disposition: form-data; name=\"model\"\r\n\r\nwhisper-1\r\n" +
+		"--golden\r\nContent-Disposition: form-data; name=\"language\"\r\n\r\nen\r\n" +
+		"--golden--\r\n"
+	if body != golden {
+		t.Errorf("unexpected body:\n%q\nexpected:\n%q", body, golden)
+	}
+}
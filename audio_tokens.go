@@ -0,0 +1,51 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrSegmentTokensMismatch = errors.New("segment text does not match its decoded tokens")
+)
+
+// Tokenizer decodes the token IDs of AudioSegment.Tokens, e.g. a binding of the tokenizer used by
+// the transcription model. The package ships none, as the vocabulary depends on the model.
+type Tokenizer interface {
+	Decode(tokens []int) string
+}
+
+// DecodeTokens returns a copy of the response whose segments without Text are filled in from their
+// Tokens using tokenizer. Segments with both are verified instead, ignoring differences in
+// whitespace: the first mismatch is reported with ErrSegmentTokensMismatch, alongside the response
+// filled in regardless. An empty Text is rebuilt from the segments. A nil tokenizer returns the
+// response unchanged.
+func (r AudioResponse) DecodeTokens(tokenizer Tokenizer) (AudioResponse, error) {
+	if tokenizer == nil {
+		return r, nil
+	}
+
+	decoded := r
+	decoded.Segments = make([]AudioSegment, len(r.Segments))
+	copy(decoded.Segments, r.Segments)
+	var err error
+	for i := range decoded.Segments {
+		segment := &decoded.Segments[i]
+		if len(segment.Tokens) == 0 {
+			continue
+		}
+		text := tokenizer.Decode(segment.Tokens)
+		switch {
+		case segment.Text == "":
+			segment.Text = text
+		case err == nil && strings.Join(strings.Fields(segment.Text), " ") != strings.Join(strings.Fields(text), " "):
+			err = fmt.Errorf("%w: segment %d has %q, tokens decode to %q", ErrSegmentTokensMismatch,
+				segment.ID, segment.Text, text)
+		}
+	}
+	if decoded.Text == "" {
+		decoded.Text = decoded.segmentsText(decoded.Segments)
+	}
+	return decoded, err
+}
@@ -0,0 +1,62 @@
+package openai_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// fakeTokenizer decodes every token as the vocabulary entry at its index.
+type fakeTokenizer []string
+
+func (f fakeTokenizer) Decode(tokens []int) string {
+	var text strings.Builder
+	for _, token := range tokens {
+		text.WriteString(f[token])
+	}
+	return text.String()
+}
+
+func TestDecodeTokens(t *testing.T) {
+	tokenizer := fakeTokenizer{" Hello", " there", ".", " General", " Kenobi", "!"}
+	res := openai.AudioResponse{
+		Segments: []openai.AudioSegment{
+			{ID: 0, Text: " Hello  there.", Tokens: []int{0, 1, 2}},
+			{ID: 1, Tokens: []int{3, 4, 5}},
+			{ID: 2, Text: " Untokenized."},
+		},
+	}
+
+	decoded, err := res.DecodeTokens(tokenizer)
+	checks.NoError(t, err, "DecodeTokens error")
+	if decoded.Segments[1].Text != " General Kenobi!" || decoded.Segments[2].Text != " Untokenized." {
+		t.Errorf("unexpected decoded segments: %+v", decoded.Segments)
+	}
+	if res.Segments[1].Text != "" {
+		t.Error("DecodeTokens modified the original response")
+	}
+	if decoded.Text != "Hello  there. General Kenobi! Untokenized." {
+		t.Errorf("expected Text to be rebuilt from the segments, got %q", decoded.Text)
+	}
+	res.Text = "As sent."
+	if decoded, _ = res.DecodeTokens(tokenizer); decoded.Text != "As sent." {
+		t.Errorf("expected a non-empty Text to be kept, got %q", decoded.Text)
+	}
+	res.Text = ""
+
+	res.Segments[0].Text = " Hello here."
+	decoded, err = res.DecodeTokens(tokenizer)
+	if !errors.Is(err, openai.ErrSegmentTokensMismatch) {
+		t.Errorf("expected ErrSegmentTokensMismatch, got %v", err)
+	}
+	if decoded.Segments[1].Text != " General Kenobi!" {
+		t.Errorf("expected the response to be filled in despite the mismatch, got %+v", decoded.Segments)
+	}
+
+	if unchanged, noErr := res.DecodeTokens(nil); noErr != nil || unchanged.Segments[1].Text != "" {
+		t.Errorf("expected a nil tokenizer to be a no-op, got %+v, %v", unchanged.Segments, noErr)
+	}
+}
@@ -0,0 +1,61 @@
+package openai
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTrimUnsupportedFormat = errors.New("audio offsets are only supported for WAV input")
+	ErrInvalidAudioOffsets   = errors.New("invalid audio offsets")
+)
+
+// hasOffsets reports whether the request selects a range of its input.
+func (r AudioRequest) hasOffsets() bool {
+	return r.StartOffset != 0 || r.EndOffset != 0
+}
+
+// trimAudioRequest returns a copy of the request whose input only holds the range selected by
+// StartOffset and EndOffset, with both offsets cleared.
+func trimAudioRequest(request AudioRequest) (AudioRequest, error) {
+	wav, err := readWAVInput(request, ErrTrimUnsupportedFormat)
+	if err != nil {
+		return AudioRequest{}, err
+	}
+	if wav, err = request.trimWAV(wav); err != nil {
+		return AudioRequest{}, err
+	}
+	request.Reader = bytes.NewReader(wav.encode())
+	request.DataURI = ""
+	request.FilePath, request.Compressed = request.uploadFilename(), false
+	request.StartOffset, request.EndOffset = 0, 0
+	return request, nil
+}
+
+// trimWAV returns the part of wav selected by StartOffset and EndOffset.
+func (r AudioRequest) trimWAV(wav wavAudio) (wavAudio, error) {
+	if !r.hasOffsets() {
+		return wav, nil
+	}
+	duration := wav.duration()
+	switch {
+	case r.StartOffset < 0 || r.EndOffset < 0:
+		return wavAudio{}, fmt.Errorf("%w: offsets must not be negative", ErrInvalidAudioOffsets)
+	case r.EndOffset != 0 && r.EndOffset <= r.StartOffset:
+		return wavAudio{}, fmt.Errorf("%w: end %v is not after start %v", ErrInvalidAudioOffsets, r.EndOffset, r.StartOffset)
+	case r.StartOffset >= duration:
+		return wavAudio{}, fmt.Errorf("%w: start %v is beyond the %v of audio",
+			ErrInvalidAudioOffsets, r.StartOffset, duration)
+	}
+
+	frameAt := func(d time.Duration) int {
+		return int(d * time.Duration(wav.SampleRate) / time.Second)
+	}
+	end := wav.frames()
+	if r.EndOffset != 0 {
+		end = frameAt(r.EndOffset)
+	}
+	return wav.slice(frameAt(r.StartOffset), end), nil
+}
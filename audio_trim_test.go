@@ -0,0 +1,81 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestAudioRequestOffsets(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var uploaded int
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		uploaded = len(data)
+		fmt.Fprint(w, `{"duration":1,"text":"clip","segments":[{"id":0,"start":0.1,"end":0.9,"text":" clip"}],`+
+			`"words":[{"word":"clip","start":0.2,"end":0.6}]}`)
+	})
+
+	res, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:       openai.Whisper1,
+		FilePath:    "long.wav",
+		Reader:      bytes.NewReader(silentWAV(3 * time.Second)),
+		Format:      openai.AudioResponseFormatVerboseJSON,
+		StartOffset: time.Second,
+		EndOffset:   2 * time.Second,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if want := 44 + 8000*2; uploaded != want {
+		t.Errorf("expected a one second clip of %d bytes to be uploaded, got %d", want, uploaded)
+	}
+	if segment := res.Segments[0]; segment.Start != 1.1 || segment.End != 1.9 {
+		t.Errorf("segment timings not shifted by the start offset: %+v", segment)
+	}
+	if word := res.Words[0]; word.Start != 1.2 || word.End != 1.6 {
+		t.Errorf("word timings not shifted by the start offset: %+v", word)
+	}
+}
+
+func TestAudioRequestOffsetsErrors(t *testing.T) {
+	client, _, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	_, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:       openai.Whisper1,
+		FilePath:    "long.mp3",
+		Reader:      strings.NewReader("ID3 not a wav"),
+		StartOffset: time.Second,
+	})
+	checks.ErrorIs(t, err, openai.ErrTrimUnsupportedFormat, "mp3 input cannot be trimmed")
+
+	for _, offsets := range [][2]time.Duration{
+		{-time.Second, 0},
+		{2 * time.Second, time.Second},
+		{5 * time.Second, 0},
+	} {
+		_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:       openai.Whisper1,
+			FilePath:    "long.wav",
+			Reader:      bytes.NewReader(silentWAV(3 * time.Second)),
+			StartOffset: offsets[0],
+			EndOffset:   offsets[1],
+		})
+		checks.ErrorIs(t, err, openai.ErrInvalidAudioOffsets, fmt.Sprintf("offsets %v should be rejected", offsets))
+	}
+}
@@ -119,7 +119,7 @@ func TestO1ModelsChatCompletionsBetaLimitations(t *testing.T) {
 						Role: openai.ChatMessageRoleAssistant,
 					},
 				},
-				Temperature: float32(2),
+				Temperature: float32Ptr(2),
 			},
 			expectedError: openai.ErrReasoningModelLimitationsOther,
 		},
@@ -136,7 +136,7 @@ func TestO1ModelsChatCompletionsBetaLimitations(t *testing.T) {
 						Role: openai.ChatMessageRoleAssistant,
 					},
 				},
-				Temperature: float32(1),
+				Temperature: float32Ptr(1),
 				TopP:        float32(0.1),
 			},
 			expectedError: openai.ErrReasoningModelLimitationsOther,
@@ -154,7 +154,7 @@ func TestO1ModelsChatCompletionsBetaLimitations(t *testing.T) {
 						Role: openai.ChatMessageRoleAssistant,
 					},
 				},
-				Temperature: float32(1),
+				Temperature: float32Ptr(1),
 				TopP:        float32(1),
 				N:           2,
 			},
@@ -239,7 +239,7 @@ func TestO3ModelsChatCompletionsBetaLimitations(t *testing.T) {
 						Role: openai.ChatMessageRoleAssistant,
 					},
 				},
-				Temperature: float32(2),
+				Temperature: float32Ptr(2),
 			},
 			expectedError: openai.ErrReasoningModelLimitationsOther,
 		},
@@ -256,7 +256,7 @@ func TestO3ModelsChatCompletionsBetaLimitations(t *testing.T) {
 						Role: openai.ChatMessageRoleAssistant,
 					},
 				},
-				Temperature: float32(1),
+				Temperature: float32Ptr(1),
 				TopP:        float32(0.1),
 			},
 			expectedError: openai.ErrReasoningModelLimitationsOther,
@@ -274,7 +274,7 @@ func TestO3ModelsChatCompletionsBetaLimitations(t *testing.T) {
 						Role: openai.ChatMessageRoleAssistant,
 					},
 				},
-				Temperature: float32(1),
+				Temperature: float32Ptr(1),
 				TopP:        float32(1),
 				N:           2,
 			},
@@ -1085,3 +1085,7 @@ func TestChatCompletionRequest_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func float32Ptr(f float32) *float32 {
+	return &f
+}
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -21,16 +22,32 @@ type Client struct {
 	createFormBuilder func(io.Writer) utils.FormBuilder
 }
 
+// FormBuilder writes the multipart/form-data bodies of file uploads, see ClientConfig.FormBuilderFactory.
+type FormBuilder = utils.FormBuilder
+
+// NewFormBuilder returns the default FormBuilder writing to body, e.g. for a custom FormBuilder to
+// delegate to.
+func NewFormBuilder(body io.Writer) FormBuilder {
+	return utils.NewFormBuilder(body)
+}
+
 type Response interface {
 	SetHeader(http.Header)
 }
 
+// strictResponse decodes the JSON body into Response, rejecting fields it does not model.
+type strictResponse struct {
+	Response
+}
+
 type httpHeader http.Header
 
 func (h *httpHeader) SetHeader(header http.Header) {
 	*h = httpHeader(header)
 }
 
+// Header returns every header of the HTTP response, including the ones that are not modeled by
+// the response type, e.g. headers added by a proxy.
 func (h *httpHeader) Header() http.Header {
 	return http.Header(*h)
 }
@@ -45,6 +62,16 @@ type RawResponse struct {
 	httpHeader
 }
 
+// WriteTo implements io.WriterTo: it streams the whole body to w and closes it, so that
+// io.Copy(w, response) or response.WriteTo(w) is all that is needed to forward a response.
+func (r RawResponse) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.Copy(w, r.ReadCloser)
+	if closeErr := r.ReadCloser.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
 // NewClient creates new OpenAI API client.
 func NewClient(authToken string) *Client {
 	config := DefaultConfig(authToken)
@@ -53,12 +80,14 @@ func NewClient(authToken string) *Client {
 
 // NewClientWithConfig creates new OpenAI API client for specified config.
 func NewClientWithConfig(config ClientConfig) *Client {
+	createFormBuilder := config.FormBuilderFactory
+	if createFormBuilder == nil {
+		createFormBuilder = NewFormBuilder
+	}
 	return &Client{
-		config:         config,
-		requestBuilder: utils.NewRequestBuilder(),
-		createFormBuilder: func(body io.Writer) utils.FormBuilder {
-			return utils.NewFormBuilder(body)
-		},
+		config:            config,
+		requestBuilder:    utils.NewRequestBuilder(),
+		createFormBuilder: createFormBuilder,
 	}
 }
 
@@ -72,8 +101,10 @@ func NewOrgClient(authToken, org string) *Client {
 }
 
 type requestOptions struct {
-	body   any
-	header http.Header
+	body          any
+	header        http.Header
+	httpClient    HTTPDoer
+	audioEndpoint string
 }
 
 type requestOption func(*requestOptions)
@@ -104,6 +135,27 @@ func withContentType(contentType string) requestOption {
 	}
 }
 
+// withHTTPClient overrides the client's configured HTTPClient for a single request.
+func withHTTPClient(doer HTTPDoer) requestOption {
+	return func(args *requestOptions) {
+		args.httpClient = doer
+	}
+}
+
+// withAudioEndpoint marks the request as an audio call, reported to ClientConfig.AudioMetricsFunc
+// and retried according to ClientConfig.RetryClassifier.
+func withAudioEndpoint(endpoint string) requestOption {
+	return func(args *requestOptions) {
+		args.audioEndpoint = endpoint
+	}
+}
+
+func withAccept(accept string) requestOption {
+	return func(args *requestOptions) {
+		args.header.Set("Accept", accept)
+	}
+}
+
 func withBetaAssistantVersion(version string) requestOption {
 	return func(args *requestOptions) {
 		args.header.Set("OpenAI-Beta", fmt.Sprintf("assistants=%s", version))
@@ -119,21 +171,85 @@ func (c *Client) newRequest(ctx context.Context, method, url string, setters ...
 	for _, setter := range setters {
 		setter(args)
 	}
+	if args.httpClient != nil {
+		ctx = ContextWithHTTPClient(ctx, args.httpClient)
+	}
+	if args.audioEndpoint != "" {
+		ctx = ContextWithHTTPClient(ctx, c.audioHTTPClient(ctx, args.audioEndpoint))
+		if c.config.Locale != "" {
+			args.header.Set("Accept-Language", c.config.Locale)
+		}
+	}
 	req, err := c.requestBuilder.Build(ctx, method, url, args.body, args.header)
 	if err != nil {
 		return nil, err
 	}
 	c.setCommonHeaders(req)
+	if project, ok := ctx.Value(projectContextKey{}).(string); ok && project != "" {
+		req.Header.Set("OpenAI-Project", project)
+	}
 	return req, nil
 }
 
+type projectContextKey struct{}
+
+// ContextWithProject returns a copy of ctx that makes any call using it send project in the
+// OpenAI-Project header, overriding ClientConfig.Project. It allows attributing cost per call.
+func ContextWithProject(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, projectContextKey{}, project)
+}
+
+type httpClientContextKey struct{}
+
+// ContextWithHTTPClient returns a copy of ctx that makes any call using it send its
+// request through doer instead of the client's configured HTTPClient. It is useful
+// when a single call needs a custom transport, e.g. mTLS to a private backend.
+func ContextWithHTTPClient(ctx context.Context, doer HTTPDoer) context.Context {
+	return context.WithValue(ctx, httpClientContextKey{}, doer)
+}
+
+// httpClient returns the HTTPDoer to use for req, falling back to the client's default.
+func (c *Client) httpClient(req *http.Request) HTTPDoer {
+	return c.httpClientFromContext(req.Context())
+}
+
+// audioHTTPClient decorates the HTTPDoer used for an audio call with retries and metrics, when
+// configured. Metrics wrap the retries so that AudioMetricsFunc is called once per call.
+func (c *Client) audioHTTPClient(ctx context.Context, endpoint string) HTTPDoer {
+	doer := c.httpClientFromContext(ctx)
+	if c.config.RetryClassifier != nil {
+		doer = &retryingHTTPDoer{
+			doer:       doer,
+			classify:   c.config.RetryClassifier,
+			maxRetries: c.config.maxRetries(),
+		}
+	}
+	if c.config.AudioMetricsFunc != nil {
+		doer = &meteredHTTPDoer{
+			doer:     doer,
+			endpoint: endpoint,
+			report:   c.config.AudioMetricsFunc,
+		}
+	}
+	return doer
+}
+
+func (c *Client) httpClientFromContext(ctx context.Context) HTTPDoer {
+	if doer, ok := ctx.Value(httpClientContextKey{}).(HTTPDoer); ok && doer != nil {
+		return doer
+	}
+	return c.config.HTTPClient
+}
+
 func (c *Client) sendRequest(req *http.Request, v Response) error {
 	_, err := c.sendRequestRawResp(req, v)
 	return err
 }
 
 func (c *Client) sendRequestRawResp(req *http.Request, v Response) (resp *http.Response, err error) {
-	req.Header.Set("Accept", "application/json")
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
 
 	// Check whether Content-Type is already set, Upload Files API requires
 	// Content-Type == multipart/form-data
@@ -142,7 +258,7 @@ func (c *Client) sendRequestRawResp(req *http.Request, v Response) (resp *http.R
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	res, err := c.config.HTTPClient.Do(req)
+	res, err := c.httpClient(req).Do(req)
 	if err != nil {
 		return resp, err
 	}
@@ -157,19 +273,40 @@ func (c *Client) sendRequestRawResp(req *http.Request, v Response) (resp *http.R
 		return res, c.handleErrorResp(res)
 	}
 
+	if text, ok := v.(*audioTextResponse); ok {
+		return res, decodeAudioText(res, text)
+	}
 	if err := decodeResponse(res.Body, v); err != nil {
 		return res, err
 	}
 	return res, nil
 }
 
+// decodeAudioText reads a text, srt or vtt transcription, decompressing it when a gateway gzipped
+// it without the transport having asked for it (the transport strips the header when it decodes itself).
+func decodeAudioText(res *http.Response, output *audioTextResponse) error {
+	if res.Uncompressed || !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return decodeString(res.Body, &output.Text)
+	}
+	reader, err := gzip.NewReader(res.Body)
+	if err != nil {
+		return fmt.Errorf("decompressing response body: %w", err)
+	}
+	if err = decodeString(reader, &output.Text); err != nil {
+		_ = reader.Close()
+		return fmt.Errorf("decompressing response body: %w", err)
+	}
+	return reader.Close()
+}
+
 func (c *Client) sendRequestRaw(req *http.Request) (response RawResponse, err error) {
-	resp, err := c.config.HTTPClient.Do(req) //nolint:bodyclose // body should be closed by outer function
+	resp, err := c.httpClient(req).Do(req) //nolint:bodyclose // body should be closed by outer function
 	if err != nil {
 		return
 	}
 
 	if isFailureStatusCode(resp) {
+		defer resp.Body.Close()
 		err = c.handleErrorResp(resp)
 		return
 	}
@@ -180,12 +317,15 @@ func (c *Client) sendRequestRaw(req *http.Request) (response RawResponse, err er
 }
 
 func sendRequestStream[T streamable](client *Client, req *http.Request) (*streamReader[T], error) {
-	req.Header.Set("Content-Type", "application/json")
+	// Keep a Content-Type set by the caller, streaming transcriptions are multipart/form-data.
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
 
-	resp, err := client.config.HTTPClient.Do(req) //nolint:bodyclose // body is closed in stream.Close()
+	resp, err := client.httpClient(req).Do(req) //nolint:bodyclose // body is closed in stream.Close()
 	if err != nil {
 		return &streamReader[T]{
 			RawResponse: resp,
@@ -235,6 +375,10 @@ func (c *Client) setCommonHeaders(req *http.Request) {
 	if c.config.OrgID != "" {
 		req.Header.Set("OpenAI-Organization", c.config.OrgID)
 	}
+
+	if c.config.Project != "" {
+		req.Header.Set("OpenAI-Project", c.config.Project)
+	}
 }
 
 func isFailureStatusCode(resp *http.Response) bool {
@@ -251,6 +395,10 @@ func decodeResponse(body io.Reader, v any) error {
 		return decodeString(body, o)
 	case *audioTextResponse:
 		return decodeString(body, &o.Text)
+	case *strictResponse:
+		decoder := json.NewDecoder(body)
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(o.Response)
 	default:
 		return json.NewDecoder(body).Decode(v)
 	}
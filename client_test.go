@@ -447,7 +447,7 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 			return client.ListRunSteps(ctx, "", "", Pagination{})
 		}},
 		{"CreateSpeech", func() (any, error) {
-			return client.CreateSpeech(ctx, CreateSpeechRequest{Model: TTSModel1, Voice: VoiceAlloy})
+			return client.CreateSpeech(ctx, CreateSpeechRequest{Model: TTSModel1, Input: "Hello!", Voice: VoiceAlloy})
 		}},
 		{"CreateBatch", func() (any, error) {
 			return client.CreateBatch(ctx, CreateBatchRequest{})
@@ -586,3 +586,115 @@ func TestClient_baseURLWithAzureDeployment(t *testing.T) {
 		})
 	}
 }
+
+// recordingHTTPDoer records whether it was used and replies with a canned response.
+type recordingHTTPDoer struct {
+	called bool
+}
+
+func (d *recordingHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	d.called = true
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewBufferString(`{"text":"hello"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestPerCallHTTPClient(t *testing.T) {
+	errDefault := errors.New("default client must not be used")
+	client := NewClient("test-token")
+	client.config.HTTPClient = &errorHTTPClient{err: errDefault}
+
+	t.Run("request option", func(t *testing.T) {
+		doer := &recordingHTTPDoer{}
+		req, err := client.newRequest(context.Background(), http.MethodGet, client.fullURL("/models"), withHTTPClient(doer))
+		checks.NoError(t, err, "newRequest error")
+
+		var res audioTextResponse
+		err = client.sendRequest(req, &res)
+		checks.NoError(t, err, "sendRequest should use the per-call client")
+		if !doer.called {
+			t.Fatal("per-call HTTP client was not used")
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		doer := &recordingHTTPDoer{}
+		ctx := ContextWithHTTPClient(context.Background(), doer)
+		req, err := client.newRequest(ctx, http.MethodGet, client.fullURL("/audio/speech"))
+		checks.NoError(t, err, "newRequest error")
+
+		res, err := client.sendRequestRaw(req)
+		checks.NoError(t, err, "sendRequestRaw should use the per-call client")
+		res.Close()
+		if !doer.called {
+			t.Fatal("context HTTP client was not used")
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		req, err := client.newRequest(context.Background(), http.MethodGet, client.fullURL("/models"))
+		checks.NoError(t, err, "newRequest error")
+
+		err = client.sendRequest(req, nil)
+		checks.ErrorIs(t, err, errDefault, "sendRequest should fall back to the configured client")
+	})
+}
+
+func TestProjectHeader(t *testing.T) {
+	config := DefaultConfig("test-token")
+	config.Project = "proj_default"
+	client := NewClientWithConfig(config)
+
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{"client level", context.Background(), "proj_default"},
+		{"context", ContextWithProject(context.Background(), "proj_ctx"), "proj_ctx"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := client.newRequest(tc.ctx, http.MethodPost, client.fullURL("/audio/speech"))
+			checks.NoError(t, err, "newRequest error")
+			if got := req.Header.Get("OpenAI-Project"); got != tc.expected {
+				t.Errorf("expected OpenAI-Project %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	req, err := NewClient("test-token").newRequest(context.Background(), http.MethodPost, "http://example.com")
+	checks.NoError(t, err, "newRequest error")
+	if _, ok := req.Header["Openai-Project"]; ok {
+		t.Error("OpenAI-Project must not be sent when no project is configured")
+	}
+}
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestRawResponseWriteTo(t *testing.T) {
+	payload := bytes.Repeat([]byte("audio"), 10000)
+	body := &closeTrackingBody{Reader: bytes.NewReader(payload)}
+	response := RawResponse{ReadCloser: body}
+
+	var out bytes.Buffer
+	n, err := io.Copy(&out, response)
+	checks.NoError(t, err, "io.Copy error")
+	if n != int64(len(payload)) || !bytes.Equal(out.Bytes(), payload) {
+		t.Fatalf("expected %d bytes to be streamed, got %d", len(payload), n)
+	}
+	if !body.closed {
+		t.Fatal("expected WriteTo to close the body")
+	}
+}
@@ -34,6 +34,8 @@ const (
 	GPT4oLatest             = "chatgpt-4o-latest"
 	GPT4oMini               = "gpt-4o-mini"
 	GPT4oMini20240718       = "gpt-4o-mini-2024-07-18"
+	GPT4oAudioPreview       = "gpt-4o-audio-preview"
+	GPT4oMiniAudioPreview   = "gpt-4o-mini-audio-preview"
 	GPT4Turbo               = "gpt-4-turbo"
 	GPT4Turbo20240409       = "gpt-4-turbo-2024-04-09"
 	GPT4Turbo0125           = "gpt-4-0125-preview"
@@ -124,6 +126,8 @@ var disabledModelsForEndpoints = map[string]map[string]bool{
 		GPT4oLatest:             true,
 		GPT4oMini:               true,
 		GPT4oMini20240718:       true,
+		GPT4oAudioPreview:       true,
+		GPT4oMiniAudioPreview:   true,
 		GPT4TurboPreview:        true,
 		GPT4VisionPreview:       true,
 		GPT4Turbo1106:           true,
@@ -1,13 +1,18 @@
 package openai
 
 import (
+	"crypto/tls"
+	"io"
+	"net"
 	"net/http"
 	"regexp"
+	"time"
 )
 
 const (
 	openaiAPIURLv1                 = "https://api.openai.com/v1"
 	defaultEmptyMessagesLimit uint = 300
+	defaultMaxRetries              = 2
 
 	azureAPIPrefix         = "openai"
 	azureDeploymentsPrefix = "deployments"
@@ -39,6 +44,7 @@ type ClientConfig struct {
 
 	BaseURL                        string
 	OrgID                          string
+	Project                        string // sent as OpenAI-Project, see ContextWithProject to override per call
 	APIType                        APIType
 	APIVersion                     string // required when APIType is APITypeAzure or APITypeAzureAD or APITypeAnthropic
 	APIProviderDisableContentCheck string // disable content check for API provider content tts checks. []
@@ -48,6 +54,68 @@ type ClientConfig struct {
 	HTTPClient                     HTTPDoer
 
 	EmptyMessagesLimit uint
+
+	// AudioMetricsFunc, when set, is called once per transcription, translation and speech call
+	// with the sizes, status and duration of the exchange. It must be cheap; it runs on the caller's goroutine.
+	// Retries made according to RetryClassifier belong to the same call: Duration includes them and
+	// StatusCode is the one of the last attempt.
+	AudioMetricsFunc func(AudioCallMetrics)
+
+	// RetryClassifier, when set, decides whether a failed transcription, translation or speech call
+	// is retried and how long to wait first. DefaultRetryClassifier is a sensible starting point.
+	RetryClassifier RetryClassifier
+	// MaxRetries bounds the retries allowed by RetryClassifier. Defaults to 2 when zero.
+	MaxRetries int
+
+	// StrictJSON makes transcription and translation calls fail when a JSON response contains fields
+	// AudioResponse does not model, to catch API drift early. It is opt-in because SenseASR and other
+	// compatible backends add extension fields.
+	StrictJSON bool
+
+	// StrictAudioInput makes audio calls fail with ErrAmbiguousAudioInput when a request has a Reader
+	// or DataURI and its FilePath names an existing file, which usually means one of them was set by
+	// mistake. By default the Reader or DataURI is uploaded and FilePath only names it.
+	StrictAudioInput bool
+
+	// MaxUploadBytes caps the size of audio request bodies, for gateways with limits below the 25MB
+	// of the OpenAI API. Larger requests fail with ErrUploadTooLarge before being sent, and
+	// CreateTranscriptionChunked sizes its chunks to fit. Zero means no limit.
+	MaxUploadBytes int64
+
+	// SpeechCache, when set, is consulted by CreateSpeech and its variants before synthesizing, and
+	// populated with every successful result, see SpeechCacheKey. Streaming requests bypass it.
+	SpeechCache SpeechCache
+
+	// AudioFormatResolver, when set, maps the filename of uploaded audio to the Content-Type of the
+	// upload, e.g. for extensions the backend does not recognize such as ".m4b". When it returns
+	// false, or for data URIs, the upload is sent as usual.
+	AudioFormatResolver func(filename string) (mimeType string, ok bool)
+
+	// DefaultPromptPrefix and DefaultPromptSuffix are concatenated, as is, before and after the
+	// Prompt of every transcription and translation request, including requests without a Prompt.
+	DefaultPromptPrefix string
+	DefaultPromptSuffix string
+
+	// PromptFieldName is the multipart form field carrying AudioRequest.Prompt, "prompt" when empty.
+	// Some OpenAI-compatible Whisper servers expect "initial_prompt" instead.
+	PromptFieldName string
+
+	// Locale, when set, is sent as the Accept-Language header of transcription, translation and
+	// speech requests, for backends localizing their error messages, see WithLocale. It only affects
+	// messages from the server: set AudioRequest.Language for the language of the audio.
+	Locale string
+
+	// FormBuilderFactory, when set, creates the FormBuilder of every multipart upload (audio, files
+	// and image edits) writing to body, e.g. to sign or instrument uploads. Its FormDataContentType
+	// is sent as the Content-Type of the request. Defaults to NewFormBuilder.
+	FormBuilderFactory func(body io.Writer) FormBuilder
+
+	// KnownModels, when set, makes transcription, translation and speech calls fail fast with
+	// ErrUnknownModel for models it does not list, before anything is uploaded, to catch typos.
+	// DefaultKnownModels seeds it with the models of this package. AllowCustomModel, when set,
+	// accepts further models, e.g. the custom names routed by a proxy.
+	KnownModels      map[string]bool
+	AllowCustomModel func(model string) bool
 }
 
 func NewProviderConfig(authToken string) ClientConfig {
@@ -105,6 +173,19 @@ func DefaultAnthropicConfig(apiKey, baseURL string) ClientConfig {
 	}
 }
 
+// WithLocale returns a copy of the config with Locale set to the BCP 47 language tag, e.g. "fr-FR".
+func (c ClientConfig) WithLocale(tag string) ClientConfig {
+	c.Locale = tag
+	return c
+}
+
+func (c ClientConfig) maxRetries() int {
+	if c.MaxRetries <= 0 {
+		return defaultMaxRetries
+	}
+	return c.MaxRetries
+}
+
 func (ClientConfig) String() string {
 	return "<OpenAI API ClientConfig>"
 }
@@ -116,3 +197,59 @@ func (c ClientConfig) GetAzureDeploymentByModel(model string) string {
 
 	return model
 }
+
+// HTTPClientConfig configures the *http.Client built by NewHTTPClient.
+// Zero values leave the corresponding limit unset.
+type HTTPClientConfig struct {
+	// Timeout bounds the whole exchange, including uploading the request and reading the body.
+	// Large audio uploads usually need a generous value, or none at all.
+	Timeout time.Duration
+	// DialTimeout bounds establishing the TCP connection.
+	DialTimeout time.Duration
+	// ResponseHeaderTimeout bounds the wait for response headers once the request has been
+	// written, so a stalled server fails fast even when Timeout is long.
+	ResponseHeaderTimeout time.Duration
+	// Protocol selects the HTTP version. By default HTTP/2 is negotiated over TLS when the server
+	// supports it, multiplexing requests on one connection. Some proxies handle large multipart
+	// uploads better over HTTP/1.1, which uses one connection per concurrent request instead; with
+	// HTTP/2 a slow upload also shares its connection's flow control with the other streams.
+	Protocol HTTPProtocol
+}
+
+// HTTPProtocol is the HTTP version preference of NewHTTPClient.
+type HTTPProtocol int
+
+const (
+	// HTTPProtocolAuto negotiates HTTP/2 over TLS and falls back to HTTP/1.1.
+	HTTPProtocolAuto HTTPProtocol = iota
+	// HTTPProtocolHTTP1 always uses HTTP/1.1.
+	HTTPProtocolHTTP1
+	// HTTPProtocolHTTP2 attempts HTTP/2 over TLS, also when the transport is customized. Plain
+	// HTTP connections always use HTTP/1.1.
+	HTTPProtocolHTTP2
+)
+
+// NewHTTPClient returns an *http.Client suitable for ClientConfig.HTTPClient with
+// connection-level timeouts distinct from the overall request timeout.
+func NewHTTPClient(config HTTPClientConfig) *http.Client {
+	//nolint:errcheck // DefaultTransport is an *http.Transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: 30 * time.Second, //nolint:mnd // same as http.DefaultTransport
+	}).DialContext
+	transport.ResponseHeaderTimeout = config.ResponseHeaderTimeout
+	switch config.Protocol {
+	case HTTPProtocolAuto:
+	case HTTPProtocolHTTP1:
+		// A non-nil empty TLSNextProto map disables HTTP/2.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	case HTTPProtocolHTTP2:
+		transport.ForceAttemptHTTP2 = true
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+	}
+}
@@ -1,9 +1,17 @@
 package openai_test
 
 import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
 )
 
 func TestGetAzureDeploymentByModel(t *testing.T) {
@@ -121,3 +129,93 @@ func TestGetAzureDeploymentByModel_NoMapper(t *testing.T) {
 		t.Errorf("GetAzureDeploymentByModel(%q) = %q; want %q", model, got, model)
 	}
 }
+
+func TestNewHTTPClientResponseHeaderTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	// Accept connections and read the request, but never answer.
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	httpClient := openai.NewHTTPClient(openai.HTTPClientConfig{
+		Timeout:               time.Minute,
+		DialTimeout:           time.Second,
+		ResponseHeaderTimeout: 100 * time.Millisecond,
+	})
+	config := openai.DefaultConfig("test-token")
+	config.BaseURL = "http://" + listener.Addr().String() + "/v1"
+	config.HTTPClient = httpClient
+	client := openai.NewClientWithConfig(config)
+
+	start := time.Now()
+	_, err = client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("audio"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "timeout awaiting response headers") {
+		t.Fatalf("expected a response header timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("header timeout took too long: %v", elapsed)
+	}
+}
+
+func TestNewHTTPClientProtocol(t *testing.T) {
+	var proto string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+		_, _ = w.Write([]byte(`{"text":"ok"}`))
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+	trusted, ok := ts.Client().Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("unexpected test server transport")
+	}
+
+	testcases := []struct {
+		protocol openai.HTTPProtocol
+		proto    string
+	}{
+		{openai.HTTPProtocolAuto, "HTTP/2.0"},
+		{openai.HTTPProtocolHTTP1, "HTTP/1.1"},
+		{openai.HTTPProtocolHTTP2, "HTTP/2.0"},
+	}
+	for _, tc := range testcases {
+		httpClient := openai.NewHTTPClient(openai.HTTPClientConfig{Protocol: tc.protocol})
+		transport, isTransport := httpClient.Transport.(*http.Transport)
+		if !isTransport {
+			t.Fatal("expected an *http.Transport")
+		}
+		transport.TLSClientConfig = trusted.TLSClientConfig.Clone()
+
+		config := openai.DefaultConfig("test-token")
+		config.BaseURL = ts.URL + "/v1"
+		config.HTTPClient = httpClient
+		_, err := openai.NewClientWithConfig(config).CreateTranscription(context.Background(), openai.AudioRequest{
+			Model:    openai.Whisper1,
+			FilePath: "audio.mp3",
+			Reader:   strings.NewReader("audio"),
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+		if proto != tc.proto {
+			t.Errorf("protocol %d: expected %s, got %s", tc.protocol, tc.proto, proto)
+		}
+	}
+}
@@ -28,6 +28,12 @@ func NewFormBuilder(body io.Writer) *DefaultFormBuilder {
 	}
 }
 
+// SetBoundary replaces the random multipart boundary, e.g. to compare request bodies in tests.
+// It must be called before anything is written, see multipart.Writer.SetBoundary.
+func (fb *DefaultFormBuilder) SetBoundary(boundary string) error {
+	return fb.writer.SetBoundary(boundary)
+}
+
 func (fb *DefaultFormBuilder) CreateFormFile(fieldname string, file *os.File) error {
 	return fb.createFormFile(fieldname, file, file.Name())
 }
@@ -188,3 +188,24 @@ func TestCreateFormFileSuccess(t *testing.T) {
 		t.Fatalf("expected filename header, got %q", buf.String())
 	}
 }
+
+func TestFormBuilderSetBoundary(t *testing.T) {
+	body := &bytes.Buffer{}
+	builder := NewFormBuilder(body)
+	checks.NoError(t, builder.SetBoundary("fixed-boundary"), "SetBoundary error")
+	checks.NoError(t, builder.WriteField("model", "whisper-1"), "WriteField error")
+	checks.NoError(t, builder.Close(), "Close error")
+
+	if builder.FormDataContentType() != "multipart/form-data; boundary=fixed-boundary" {
+		t.Errorf("unexpected content type %q", builder.FormDataContentType())
+	}
+	expected := "--fixed-boundary\r\nContent-Disposition: form-data; name=\"model\"\r\n\r\n" +
+		"whisper-1\r\n--fixed-boundary--\r\n"
+	if body.String() != expected {
+		t.Errorf("unexpected body %q", body.String())
+	}
+
+	if err := NewFormBuilder(body).SetBoundary("not a valid boundary "); err == nil {
+		t.Error("expected an invalid boundary to be rejected")
+	}
+}
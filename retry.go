@@ -0,0 +1,131 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultRetryDelay = time.Second
+
+// RetryClassifier decides whether a request is retried after a failure, and how long to wait before
+// the next attempt. It is called with the response of a failed (non 2xx) exchange, whose body can be
+// read freely, or with the transport error when no response was received.
+type RetryClassifier func(resp *http.Response, err error) (retry bool, delay time.Duration)
+
+// DefaultRetryClassifier retries transport errors, 429 and 5xx responses. The delay follows the
+// Retry-After header when present and defaults to one second. Canceled requests are never retried.
+func DefaultRetryClassifier(resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false, 0
+		}
+		return true, defaultRetryDelay
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+		return false, 0
+	}
+	return true, retryAfter(resp.Header.Get("Retry-After"))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return defaultRetryDelay
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+		return 0
+	}
+	return defaultRetryDelay
+}
+
+// retryingHTTPDoer retries failed exchanges according to a RetryClassifier.
+type retryingHTTPDoer struct {
+	doer       HTTPDoer
+	classify   RetryClassifier
+	maxRetries int
+}
+
+func (d *retryingHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := d.doer.Do(req)
+		if err == nil && !isFailureStatusCode(resp) {
+			return resp, nil
+		}
+		// A body that cannot be replayed cannot be retried.
+		if attempt >= d.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		var body *bytes.Reader
+		if resp != nil {
+			if body, err = readAllBody(resp.Body); err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(body)
+		}
+
+		retry, delay := d.classify(resp, err)
+		if !retry {
+			if body != nil {
+				// Let the caller decode the error body from its start.
+				_, _ = body.Seek(0, io.SeekStart)
+			}
+			return resp, err
+		}
+
+		if err = sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readAllBody buffers an error response body in memory so it can be read more than once.
+func readAllBody(body io.ReadCloser) (*bytes.Reader, error) {
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// rewindRequest returns a copy of req with a fresh body, ready to be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+// sleepContext waits for d, returning early with the context error when ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
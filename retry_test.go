@@ -0,0 +1,176 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func setupRetryTestServer(
+	classifier openai.RetryClassifier,
+) (client *openai.Client, server *test.ServerTest, teardown func()) {
+	server = test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	teardown = ts.Close
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.RetryClassifier = classifier
+	client = openai.NewClientWithConfig(config)
+	return
+}
+
+func TestRetryClassifierCustom(t *testing.T) {
+	classifier := func(resp *http.Response, err error) (bool, time.Duration) {
+		if err != nil {
+			return false, 0
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return strings.Contains(string(body), "model_overloaded"), 0
+	}
+	client, server, teardown := setupRetryTestServer(classifier)
+	defer teardown()
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "some audio") {
+			t.Errorf("attempt %d: request body not replayed", calls)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"busy","code":"model_overloaded"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+
+	resp, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: "audio.mp3",
+		Reader:   strings.NewReader("some audio"),
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if calls != 2 || resp.Text != "hello" {
+		t.Fatalf("expected a successful second attempt, got %d calls and %q", calls, resp.Text)
+	}
+}
+
+func TestRetryClassifierDeclines(t *testing.T) {
+	classifier := func(resp *http.Response, _ error) (bool, time.Duration) {
+		_, _ = io.ReadAll(resp.Body)
+		return false, 0
+	}
+	client, server, teardown := setupRetryTestServer(classifier)
+	defer teardown()
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad voice","type":"invalid_request_error"}}`))
+	})
+
+	_, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "bad voice" {
+		t.Fatalf("expected the error body to be decoded, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestDefaultRetryClassifier(t *testing.T) {
+	client, server, teardown := setupRetryTestServer(openai.DefaultRetryClassifier)
+	defer teardown()
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.HasError(t, err, "CreateSpeech should fail once retries are exhausted")
+	if calls != 3 {
+		t.Fatalf("expected 1 attempt and 2 retries, got %d calls", calls)
+	}
+
+	for _, tc := range []struct {
+		status int
+		retry  bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusBadRequest, false},
+	} {
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		if retry, _ := openai.DefaultRetryClassifier(resp, nil); retry != tc.retry {
+			t.Errorf("status %d: expected retry %v", tc.status, tc.retry)
+		}
+	}
+	if retry, _ := openai.DefaultRetryClassifier(nil, context.Canceled); retry {
+		t.Error("canceled requests must not be retried")
+	}
+}
+
+func TestRetryReportsMetricsOnce(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var recorded []openai.AudioCallMetrics
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.RetryClassifier = func(*http.Response, error) (bool, time.Duration) { return true, 0 }
+	config.AudioMetricsFunc = func(m openai.AudioCallMetrics) {
+		recorded = append(recorded, m)
+	}
+	client := openai.NewClientWithConfig(config)
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("mp3"))
+	})
+
+	speech, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoError(t, err, "CreateSpeech error")
+	_, _ = io.ReadAll(speech)
+	speech.Close()
+
+	if calls != 2 {
+		t.Fatalf("expected a retry, got %d calls", calls)
+	}
+	if len(recorded) != 1 || recorded[0].StatusCode != http.StatusOK {
+		t.Fatalf("expected a single metrics record for the call, got %+v", recorded)
+	}
+}
@@ -1,15 +1,86 @@
 package openai
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"math"
+	"mime"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+var (
+	ErrEmptySpeechInput = errors.New("speech input must not be empty")
+	ErrInputTooLong     = errors.New("speech input is too long")
+
+	ErrSpeechInstructionsNotSupported = errors.New("speech model does not support instructions")
+	ErrSpeechStreamRequiresStreamAPI  = errors.New("streamed speech must be read with CreateSpeechStream")
+	ErrSpeechResumeUnsupported        = errors.New("speech backend does not support resuming a stream")
+	ErrSpeechVoiceCloningNotSupported = errors.New("speech model does not support reference voices or timber fusion")
+)
+
+// InputTooLongError is returned by CreateSpeechRequest.Validate when Input exceeds the maximum
+// length. It matches ErrInputTooLong with errors.Is.
+type InputTooLongError struct {
+	Length int // length of the input, in characters
+	Max    int // maximum accepted length, in characters
+}
+
+func (e *InputTooLongError) Error() string {
+	return fmt.Sprintf("%s: %d characters, maximum is %d", ErrInputTooLong, e.Length, e.Max)
+}
+
+func (e *InputTooLongError) Is(target error) bool {
+	return target == ErrInputTooLong //nolint:errorlint // sentinel comparison
+}
+
+// speechInputLimits are the documented maximum input lengths, in characters, per model.
+var speechInputLimits = map[SpeechModel]int{
+	TTSModel1:         4096,
+	TTSModel1HD:       4096,
+	TTSModelGPT4oMini: 4096,
+}
+
+// speechModelsWithoutInstructions are the models known to ignore CreateSpeechRequest.Instructions.
+var speechModelsWithoutInstructions = map[SpeechModel]bool{
+	TTSModel1:   true,
+	TTSModel1HD: true,
+}
+
+// speechModelsWithoutVoiceCloning are the models known to ignore ReferenceVoiceWav and TimberWeights.
+var speechModelsWithoutVoiceCloning = map[SpeechModel]bool{
+	TTSModel1:         true,
+	TTSModel1HD:       true,
+	TTSModelGPT4oMini: true,
+}
+
+// speechModelsWithDeltaStream are the models that answer a Stream request with delta events
+// instead of plain audio bytes.
+var speechModelsWithDeltaStream = map[SpeechModel]bool{
+	TTSModelCanary: true,
+}
+
 type SpeechModel string
 
+// SupportsInstructions reports whether the model follows CreateSpeechRequest.Instructions.
+// Models this package does not know about, such as custom deployments, are assumed to support them.
+func (m SpeechModel) SupportsInstructions() bool {
+	return !speechModelsWithoutInstructions[m]
+}
+
+// SupportsVoiceCloning reports whether the model follows CreateSpeechRequest.ReferenceVoiceWav and
+// TimberWeights, like canary-tts. Models this package does not know about are assumed to.
+func (m SpeechModel) SupportsVoiceCloning() bool {
+	return !speechModelsWithoutVoiceCloning[m]
+}
+
 const (
 	TTSModel1         SpeechModel = "tts-1"
 	TTSModel1HD       SpeechModel = "tts-1-hd"
@@ -43,6 +114,39 @@ const (
 	SpeechResponseFormatPcm  SpeechResponseFormat = "pcm"
 )
 
+// Extension returns the file extension of the format, including the dot, e.g. ".mp3". It returns
+// "" for unknown formats.
+func (f SpeechResponseFormat) Extension() string {
+	switch f {
+	case SpeechResponseFormatMp3, SpeechResponseFormatOpus, SpeechResponseFormatAac,
+		SpeechResponseFormatFlac, SpeechResponseFormatWav, SpeechResponseFormatPcm:
+		return "." + string(f)
+	default:
+		return ""
+	}
+}
+
+// MIMEType returns the media type of the format, e.g. "audio/mpeg". Raw PCM is reported as
+// "audio/pcm". It returns "" for unknown formats.
+func (f SpeechResponseFormat) MIMEType() string {
+	switch f {
+	case SpeechResponseFormatMp3:
+		return "audio/mpeg"
+	case SpeechResponseFormatOpus:
+		return "audio/opus"
+	case SpeechResponseFormatAac:
+		return "audio/aac"
+	case SpeechResponseFormatFlac:
+		return "audio/flac"
+	case SpeechResponseFormatWav:
+		return "audio/wav"
+	case SpeechResponseFormatPcm:
+		return "audio/pcm"
+	default:
+		return ""
+	}
+}
+
 type FloatFrac float64
 
 func (f FloatFrac) MarshalJSON() ([]byte, error) {
@@ -61,7 +165,7 @@ type CreateSpeechRequest struct {
 	Model             SpeechModel          `json:"model"`
 	Input             string               `json:"input"`
 	Voice             SpeechVoice          `json:"voice"`
-	Instructions      string               `json:"instructions,omitempty"`        // Optional, Doesnt work with tts-1 or tts-1-hd.
+	Instructions      string               `json:"instructions,omitempty"`        // Optional, rejected by Validate for tts-1 and tts-1-hd.
 	ResponseFormat    SpeechResponseFormat `json:"response_format,omitempty"`     // Optional, default to mp3
 	Speed             FloatFrac            `json:"speed,omitempty"`               // Optional, default to 1.0
 	Stream            bool                 `json:"stream,omitempty"`              // Optional, default to false
@@ -73,15 +177,112 @@ type CreateSpeechRequest struct {
 	Channel           int                  `json:"channel,omitempty"`             // 音频声道数： Optional, default to 1
 	ReferenceVoiceWav string               `json:"reference_voice_wav,omitempty"` // 参考音频路径
 	TimberWeights     map[string]FloatFrac `json:"timber_weights,omitempty"`      // 融合音色权重列表
+	Pronunciations    map[string]string    `json:"pronunciations,omitempty"`      // 发音词典：word -> IPA or respelling
+
+	// MaxInputChars overrides the maximum Input length enforced by Validate. When zero the
+	// model's documented limit is used, if known; a negative value disables the check.
+	MaxInputChars int `json:"-"`
+
+	// MaxReconnects is how many times CreateSpeechStream reconnects after the connection drops
+	// mid-stream. The new request asks for the rest of the audio with a Range header; backends
+	// that do not answer with a matching Content-Range fail with ErrSpeechResumeUnsupported.
+	// Zero disables reconnecting.
+	MaxReconnects int `json:"-"`
+
+	// OnBoundary, when set, is called by CreateSpeechStream and its variants with the word and
+	// sentence boundaries of backends streaming server-sent events: speech.audio.delta events carry
+	// the base64 encoded audio, read from the stream as plain bytes, and speech.audio.boundary events
+	// a SpeechBoundary. Responses that are not event streams are read as is, without boundaries.
+	OnBoundary func(SpeechBoundary) `json:"-"`
+}
+
+// Validate checks the request for mistakes that would make the API call fail or be wasted.
+// CreateSpeech and its variants call it before sending anything. Stream is rejected for models
+// that stream delta events, such as canary-tts, since the blocking calls cannot decode them; use
+// CreateSpeechStream for those.
+func (r CreateSpeechRequest) Validate() error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	if r.Stream && speechModelsWithDeltaStream[r.Model] {
+		return fmt.Errorf("%w: %s sends delta events when Stream is set", ErrSpeechStreamRequiresStreamAPI, r.Model)
+	}
+	return nil
+}
+
+// validate runs the checks shared by the blocking and the streaming calls.
+func (r CreateSpeechRequest) validate() error {
+	if strings.TrimSpace(r.Input) == "" {
+		return ErrEmptySpeechInput
+	}
+	if limit := r.maxInputChars(); limit > 0 {
+		if length := utf8.RuneCountInString(r.Input); length > limit {
+			return &InputTooLongError{Length: length, Max: limit}
+		}
+	}
+	if r.Instructions != "" && !r.Model.SupportsInstructions() {
+		return fmt.Errorf("%w: %s", ErrSpeechInstructionsNotSupported, r.Model)
+	}
+	if (r.ReferenceVoiceWav != "" || len(r.TimberWeights) > 0) && !r.Model.SupportsVoiceCloning() {
+		return fmt.Errorf("%w: %s", ErrSpeechVoiceCloningNotSupported, r.Model)
+	}
+	return nil
+}
+
+func (r CreateSpeechRequest) maxInputChars() int {
+	if r.MaxInputChars != 0 {
+		return r.MaxInputChars
+	}
+	return speechInputLimits[r.Model]
 }
 
 func (c *Client) CreateSpeech(ctx context.Context, request CreateSpeechRequest) (response RawResponse, err error) {
+	if err = request.Validate(); err != nil {
+		return
+	}
+	if err = c.checkKnownModel(string(request.Model)); err != nil {
+		return
+	}
+	if c.config.SpeechCache != nil && !request.Stream {
+		return c.createCachedSpeech(ctx, request)
+	}
+	return c.createSpeech(ctx, request)
+}
+
+// createCachedSpeech serves the request from the SpeechCache, synthesizing and caching it on a miss.
+func (c *Client) createCachedSpeech(ctx context.Context, request CreateSpeechRequest) (RawResponse, error) {
+	key, err := SpeechCacheKey(request)
+	if err != nil {
+		return RawResponse{}, err
+	}
+	if cached, ok := c.config.SpeechCache.Get(key); ok {
+		return cached.rawResponse(), nil
+	}
+
+	response, err := c.createSpeech(ctx, request)
+	if err != nil {
+		return RawResponse{}, err
+	}
+	defer response.Close()
+	audio, err := io.ReadAll(response)
+	if err != nil {
+		return RawResponse{}, err
+	}
+	c.config.SpeechCache.Set(key, CachedSpeech{Audio: audio, ContentType: response.Header().Get("Content-Type")})
+	return RawResponse{
+		ReadCloser: io.NopCloser(bytes.NewReader(audio)),
+		httpHeader: response.httpHeader,
+	}, nil
+}
+
+func (c *Client) createSpeech(ctx context.Context, request CreateSpeechRequest) (response RawResponse, err error) {
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL("/audio/speech", withModel(string(request.Model))),
 		withBody(request),
 		withContentType("application/json"),
+		withAudioEndpoint("/audio/speech"),
 	)
 	if err != nil {
 		return
@@ -89,3 +290,84 @@ func (c *Client) CreateSpeech(ctx context.Context, request CreateSpeechRequest)
 
 	return c.sendRequestRaw(req)
 }
+
+// SpeechFile describes the audio file written by CreateSpeechToFile.
+type SpeechFile struct {
+	Path     string               // final path, including the extension
+	Format   SpeechResponseFormat // format of the written audio
+	Warnings []string             // e.g. a path extension that does not match Format
+
+	httpHeader
+}
+
+// CreateSpeechToFile synthesizes speech and writes it to path.
+//
+// When path has no extension, one is appended based on request.ResponseFormat or, when that is
+// unset, on the Content-Type of the response (falling back to mp3, the server default).
+// An explicit extension that does not match the actual format is kept, and a warning is reported.
+// When writing fails, e.g. because the connection drops, the partial file is removed.
+func (c *Client) CreateSpeechToFile(
+	ctx context.Context,
+	request CreateSpeechRequest,
+	path string,
+) (file SpeechFile, err error) {
+	response, err := c.CreateSpeech(ctx, request)
+	if err != nil {
+		return
+	}
+	defer response.Close()
+
+	file.httpHeader = response.httpHeader
+	file.Format = speechFormatOrDefault(request, response)
+
+	file.Path = path
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	switch {
+	case ext == "":
+		file.Path = path + "." + string(file.Format)
+	case !strings.EqualFold(ext, string(file.Format)):
+		file.Warnings = append(file.Warnings,
+			fmt.Sprintf("path extension %q does not match response format %q", ext, file.Format))
+	}
+
+	f, err := os.Create(file.Path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	// Do not leave truncated audio behind when the response cannot be read to the end.
+	if _, err = io.Copy(f, response); err != nil {
+		_ = f.Close()
+		_ = os.Remove(file.Path)
+		return SpeechFile{}, err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(file.Path)
+		return SpeechFile{}, err
+	}
+	return file, nil
+}
+
+// speechFormatFromContentType maps a response Content-Type to a SpeechResponseFormat,
+// defaulting to mp3.
+func speechFormatFromContentType(contentType string) SpeechResponseFormat {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return SpeechResponseFormatMp3
+	}
+	switch mediaType {
+	case "audio/opus", "audio/ogg":
+		return SpeechResponseFormatOpus
+	case "audio/aac":
+		return SpeechResponseFormatAac
+	case "audio/flac", "audio/x-flac":
+		return SpeechResponseFormatFlac
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return SpeechResponseFormatWav
+	case "audio/pcm", "audio/l16":
+		return SpeechResponseFormatPcm
+	default:
+		return SpeechResponseFormatMp3
+	}
+}
@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// CreateSpeechBatch — synthesizes every request into its own file in dir, running at most
+// concurrency requests at a time (1 when concurrency is not positive). Files are named after the
+// request index, e.g. "speech-07.mp3", the extension following the response format as in
+// CreateSpeechToFile. The returned paths and errors are in the order of requests: a failed item
+// has an empty path and a non-nil error, and does not stop the others.
+func (c *Client) CreateSpeechBatch(
+	ctx context.Context,
+	requests []CreateSpeechRequest,
+	dir string,
+	concurrency int,
+) ([]string, []error) {
+	paths := make([]string, len(requests))
+	errs := make([]error, len(requests))
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	width := len(strconv.Itoa(len(requests) - 1))
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range requests {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("speech %d: %w", i, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			name := fmt.Sprintf("speech-%0*d", width, i)
+			if format := requests[i].ResponseFormat; format != "" {
+				name += "." + string(format)
+			}
+			file, err := c.CreateSpeechToFile(ctx, requests[i], filepath.Join(dir, name))
+			if err != nil {
+				errs[i] = fmt.Errorf("speech %d: %w", i, err)
+				return
+			}
+			paths[i] = file.Path
+		}(i)
+	}
+	wg.Wait()
+	return paths, errs
+}
@@ -0,0 +1,113 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateSpeechBatch(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	const concurrency = 2
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+	)
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxFlight {
+			maxFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		// Give the other requests a chance to overlap with this one.
+		for deadline := time.Now().Add(100 * time.Millisecond); time.Now().Before(deadline); {
+			mu.Lock()
+			full := inFlight >= concurrency
+			mu.Unlock()
+			if full {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		var request openai.CreateSpeechRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.Input == "fail" {
+			http.Error(w, `{"error":{"message":"synthesis failed"}}`, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("audio:" + request.Input))
+	})
+
+	inputs := []string{"click", "fail", "beep", "", "chime"}
+	requests := make([]openai.CreateSpeechRequest, len(inputs))
+	for i, input := range inputs {
+		requests[i] = openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy, Input: input}
+	}
+	requests[2].ResponseFormat = openai.SpeechResponseFormatWav
+
+	dir := t.TempDir()
+	paths, errs := client.CreateSpeechBatch(context.Background(), requests, dir, concurrency)
+	if len(paths) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatalf("expected %d results, got %d paths and %d errors", len(inputs), len(paths), len(errs))
+	}
+
+	wantPaths := map[int]string{0: "speech-0.mp3", 2: "speech-2.wav", 4: "speech-4.mp3"}
+	for i, input := range inputs {
+		want, ok := wantPaths[i]
+		if !ok {
+			if errs[i] == nil || paths[i] != "" {
+				t.Errorf("item %d: expected an error and no path, got %q, %v", i, paths[i], errs[i])
+			}
+			continue
+		}
+		checks.NoError(t, errs[i], "CreateSpeechBatch item error")
+		if paths[i] != filepath.Join(dir, want) {
+			t.Errorf("item %d: expected path %q, got %q", i, want, paths[i])
+		}
+		data, err := os.ReadFile(paths[i])
+		checks.NoError(t, err, "ReadFile error")
+		if string(data) != "audio:"+input {
+			t.Errorf("item %d: unexpected content %q", i, data)
+		}
+	}
+	checks.ErrorIs(t, errs[3], openai.ErrEmptySpeechInput, "empty input should fail validation")
+	if maxFlight != concurrency {
+		t.Errorf("expected %d requests in flight at most, got %d", concurrency, maxFlight)
+	}
+}
+
+func TestCreateSpeechBatchCanceled(t *testing.T) {
+	client, _, teardown := setupOpenAITestServer()
+	defer teardown()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: "Hello!", Voice: openai.VoiceAlloy}
+	_, errs := client.CreateSpeechBatch(ctx, []openai.CreateSpeechRequest{request, request, request}, t.TempDir(), 1)
+	for i, err := range errs {
+		checks.ErrorIs(t, err, context.Canceled, "expected every request to be skipped")
+		if prefix := fmt.Sprintf("speech %d: ", i); err == nil || !strings.HasPrefix(err.Error(), prefix) {
+			t.Errorf("expected the error of request %d to name it, got %v", i, err)
+		}
+	}
+}
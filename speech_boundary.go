@@ -0,0 +1,102 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+)
+
+type SpeechBoundaryType string
+
+const (
+	SpeechBoundaryWord     SpeechBoundaryType = "word"
+	SpeechBoundarySentence SpeechBoundaryType = "sentence"
+)
+
+// SpeechBoundary marks where a word or a sentence of the input starts in the synthesized audio,
+// e.g. to highlight the text being spoken during playback.
+type SpeechBoundary struct {
+	Type    SpeechBoundaryType `json:"boundary"`
+	Text    string             `json:"text"`
+	StartMs int64              `json:"start_ms"`
+}
+
+// Server-sent events of a streaming speech synthesis, see CreateSpeechRequest.OnBoundary.
+const (
+	speechEventAudioDelta = "speech.audio.delta"
+	speechEventBoundary   = "speech.audio.boundary"
+)
+
+// speechStreamEvent is a server-sent event of a streaming speech synthesis.
+type speechStreamEvent struct {
+	Type  string `json:"type"`
+	Audio []byte `json:"audio"` // base64 encoded in JSON
+	SpeechBoundary
+}
+
+// isEventStream reports whether a response with the given Content-Type holds server-sent events.
+func isEventStream(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "text/event-stream"
+}
+
+// speechEventReader reads the audio carried by the server-sent events of a speech stream, passing
+// boundary events to onBoundary. Other events are skipped.
+type speechEventReader struct {
+	lines      *bufio.Reader
+	onBoundary func(SpeechBoundary)
+	pending    []byte
+	done       bool
+}
+
+func newSpeechEventReader(r io.Reader, onBoundary func(SpeechBoundary)) *speechEventReader {
+	return &speechEventReader{lines: bufio.NewReader(r), onBoundary: onBoundary}
+}
+
+func (r *speechEventReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		line, err := r.lines.ReadBytes('\n')
+		if eventErr := r.handleLine(line); eventErr != nil {
+			return 0, eventErr
+		}
+		if errors.Is(err, io.EOF) {
+			r.done = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// handleLine decodes a line of the event stream; lines other than data fields are ignored.
+func (r *speechEventReader) handleLine(line []byte) error {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return nil
+	}
+	data := bytes.TrimSpace(line[len("data:"):])
+	if string(data) == "[DONE]" {
+		r.done = true
+		return nil
+	}
+	var event speechStreamEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("decoding speech event: %w", err)
+	}
+	switch event.Type {
+	case speechEventAudioDelta:
+		r.pending = event.Audio
+	case speechEventBoundary:
+		r.onBoundary(event.SpeechBoundary)
+	}
+	return nil
+}
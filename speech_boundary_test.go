@@ -0,0 +1,75 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestSpeechStreamBoundaries(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	eventStream := true
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		if !eventStream {
+			_, _ = w.Write([]byte("plain audio"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		audio := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+		for _, event := range []string{
+			`{"type":"speech.audio.boundary","boundary":"sentence","text":"Hello there.","start_ms":0}`,
+			`{"type":"speech.audio.boundary","boundary":"word","text":"Hello","start_ms":0}`,
+			fmt.Sprintf(`{"type":"speech.audio.delta","audio":%q}`, audio("hello-")),
+			`{"type":"speech.audio.boundary","boundary":"word","text":"there","start_ms":420}`,
+			fmt.Sprintf(`{"type":"speech.audio.delta","audio":%q}`, audio("there")),
+			`{"type":"speech.audio.done"}`,
+		} {
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", event)
+		}
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	})
+
+	var boundaries []openai.SpeechBoundary
+	request := openai.CreateSpeechRequest{
+		Model:      openai.TTSModelCanary,
+		Input:      "Hello there.",
+		Voice:      openai.VoiceAlloy,
+		Stream:     true,
+		OnBoundary: func(b openai.SpeechBoundary) { boundaries = append(boundaries, b) },
+	}
+	stream, err := client.CreateSpeechStream(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	audio, err := io.ReadAll(stream)
+	stream.Close()
+	checks.NoError(t, err, "ReadAll error")
+	if string(audio) != "hello-there" {
+		t.Errorf("expected the decoded audio, got %q", audio)
+	}
+	expected := []openai.SpeechBoundary{
+		{Type: openai.SpeechBoundarySentence, Text: "Hello there.", StartMs: 0},
+		{Type: openai.SpeechBoundaryWord, Text: "Hello", StartMs: 0},
+		{Type: openai.SpeechBoundaryWord, Text: "there", StartMs: 420},
+	}
+	if !reflect.DeepEqual(boundaries, expected) {
+		t.Errorf("expected boundaries %v, got %v", expected, boundaries)
+	}
+
+	// Backends without boundaries just yield audio.
+	eventStream, boundaries = false, nil
+	stream, err = client.CreateSpeechStream(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	audio, err = io.ReadAll(stream)
+	stream.Close()
+	checks.NoError(t, err, "ReadAll error")
+	if string(audio) != "plain audio" || boundaries != nil {
+		t.Errorf("expected the audio as is without boundaries, got %q and %v", audio, boundaries)
+	}
+}
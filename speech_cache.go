@@ -0,0 +1,116 @@
+package openai
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// CachedSpeech is a synthesized speech result stored in a SpeechCache.
+type CachedSpeech struct {
+	Audio       []byte
+	ContentType string
+}
+
+// rawResponse returns the cached audio as if it had just been received.
+func (s CachedSpeech) rawResponse() RawResponse {
+	header := http.Header{}
+	if s.ContentType != "" {
+		header.Set("Content-Type", s.ContentType)
+	}
+	return RawResponse{
+		ReadCloser: io.NopCloser(bytes.NewReader(s.Audio)),
+		httpHeader: httpHeader(header),
+	}
+}
+
+// SpeechCache stores synthesized speech by SpeechCacheKey, so that identical requests are not
+// synthesized and billed twice. Implementations must be safe for concurrent use and must not
+// modify the audio they are given or return.
+type SpeechCache interface {
+	Get(key string) (CachedSpeech, bool)
+	Set(key string, speech CachedSpeech)
+}
+
+// SpeechCacheKey returns the cache key of a request: the hex SHA-256 of every field sent to the
+// API, i.e. of everything that affects the synthesized audio. Client-side options such as
+// MaxInputChars are not part of the key.
+func SpeechCacheKey(request CreateSpeechRequest) (string, error) {
+	// json.Marshal sorts map keys, so equal requests always hash the same.
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SpeechLRUCache is an in-memory SpeechCache that evicts the least recently used results once it
+// holds more than its capacity. It is safe for concurrent use.
+type SpeechLRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is the most recently used
+	entries  map[string]*list.Element
+}
+
+type speechLRUEntry struct {
+	key    string
+	speech CachedSpeech
+}
+
+func lruEntry(element *list.Element) *speechLRUEntry {
+	entry, _ := element.Value.(*speechLRUEntry)
+	return entry
+}
+
+// NewSpeechLRUCache returns a cache holding at most capacity results, at least one.
+func NewSpeechLRUCache(capacity int) *SpeechLRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &SpeechLRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *SpeechLRUCache) Get(key string) (CachedSpeech, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return CachedSpeech{}, false
+	}
+	c.order.MoveToFront(element)
+	return lruEntry(element).speech, true
+}
+
+func (c *SpeechLRUCache) Set(key string, speech CachedSpeech) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		lruEntry(element).speech = speech
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&speechLRUEntry{key: key, speech: speech})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, lruEntry(oldest).key)
+	}
+}
+
+// Len returns the number of cached results.
+func (c *SpeechLRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
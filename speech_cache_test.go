@@ -0,0 +1,130 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateSpeechCache(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.SpeechCache = openai.NewSpeechLRUCache(8)
+	client := openai.NewClientWithConfig(config)
+
+	calls := 0
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var request openai.CreateSpeechRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", "audio/wav")
+		_, _ = w.Write([]byte("audio:" + request.Input))
+	})
+
+	speak := func(request openai.CreateSpeechRequest) string {
+		t.Helper()
+		response, err := client.CreateSpeech(context.Background(), request)
+		checks.NoError(t, err, "CreateSpeech error")
+		defer response.Close()
+		if got := response.Header().Get("Content-Type"); got != "audio/wav" {
+			t.Errorf("expected Content-Type audio/wav, got %q", got)
+		}
+		data, err := io.ReadAll(response)
+		checks.NoError(t, err, "ReadAll error")
+		return string(data)
+	}
+
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy, Input: "hello"}
+	if got := speak(request); got != "audio:hello" {
+		t.Errorf("unexpected audio %q", got)
+	}
+	if got := speak(request); got != "audio:hello" {
+		t.Errorf("unexpected cached audio %q", got)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second request to be served from the cache, got %d calls", calls)
+	}
+
+	// Every synthesis-affecting field is part of the key.
+	variants := []func(r *openai.CreateSpeechRequest){
+		func(r *openai.CreateSpeechRequest) { r.Voice = openai.VoiceEcho },
+		func(r *openai.CreateSpeechRequest) { r.Speed = 1.5 },
+		func(r *openai.CreateSpeechRequest) { r.Pitch = 2 },
+		func(r *openai.CreateSpeechRequest) {
+			r.Model = openai.TTSModelCanary // timber fusion needs a supporting model
+			r.TimberWeights = map[string]openai.FloatFrac{"alloy": 1}
+		},
+	}
+	for i, variant := range variants {
+		changed := request
+		variant(&changed)
+		speak(changed)
+		if calls != i+2 {
+			t.Errorf("variant %d: expected a cache miss, got %d calls", i, calls)
+		}
+	}
+
+	// Streaming requests bypass the cache.
+	streamed := request
+	streamed.Stream = true
+	speak(streamed)
+	speak(streamed)
+	if want := len(variants) + 3; calls != want {
+		t.Errorf("expected streaming requests to bypass the cache, got %d calls, want %d", calls, want)
+	}
+}
+
+func TestSpeechCacheKey(t *testing.T) {
+	request := openai.CreateSpeechRequest{
+		Model:         openai.TTSModel1,
+		Voice:         openai.VoiceAlloy,
+		Input:         "hello",
+		TimberWeights: map[string]openai.FloatFrac{"alloy": 0.5, "echo": 0.5},
+	}
+	key, err := openai.SpeechCacheKey(request)
+	checks.NoError(t, err, "SpeechCacheKey error")
+
+	same := request
+	same.TimberWeights = map[string]openai.FloatFrac{"echo": 0.5, "alloy": 0.5}
+	same.MaxInputChars = 10
+	if sameKey, _ := openai.SpeechCacheKey(same); sameKey != key {
+		t.Errorf("expected equal requests to share a key")
+	}
+	other := request
+	other.Instructions = "whisper"
+	if otherKey, _ := openai.SpeechCacheKey(other); otherKey == key {
+		t.Errorf("expected Instructions to change the key")
+	}
+}
+
+func TestSpeechLRUCache(t *testing.T) {
+	cache := openai.NewSpeechLRUCache(2)
+	cache.Set("a", openai.CachedSpeech{Audio: []byte("a")})
+	cache.Set("b", openai.CachedSpeech{Audio: []byte("b")})
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.Set("c", openai.CachedSpeech{Audio: []byte("c")})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if speech, ok := cache.Get(key); !ok || string(speech.Audio) != key {
+			t.Errorf("expected %q to be cached, got %q, %v", key, speech.Audio, ok)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+}
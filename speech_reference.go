@@ -0,0 +1,61 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var (
+	ErrInvalidReferenceVoice = errors.New("invalid reference voice")
+)
+
+// Limits checked by ValidateReferenceVoiceWAV. Shorter or lower quality references make voice
+// cloning unreliable, longer ones are truncated by the backend.
+const (
+	ReferenceVoiceMinDuration   = 3 * time.Second
+	ReferenceVoiceMaxDuration   = 60 * time.Second
+	ReferenceVoiceMinSampleRate = 16000
+)
+
+// ValidateReferenceVoiceWAV checks that the file at path can be used as a voice cloning reference:
+// a readable PCM WAV file of at least ReferenceVoiceMinSampleRate Hz, lasting between
+// ReferenceVoiceMinDuration and ReferenceVoiceMaxDuration. Format problems are reported as errors
+// matching ErrInvalidReferenceVoice and describing the issue.
+func ValidateReferenceVoiceWAV(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading reference voice: %w", err)
+	}
+	if !isWAV(data) {
+		return fmt.Errorf("%w: %s is not a WAV file", ErrInvalidReferenceVoice, path)
+	}
+	wav, err := parseWAV(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidReferenceVoice, err) //nolint:errorlint // keep the sentinel matchable
+	}
+
+	switch duration := wav.duration(); {
+	case wav.AudioFormat != wavFormatPCM:
+		return fmt.Errorf("%w: audio format %d is not PCM", ErrInvalidReferenceVoice, wav.AudioFormat)
+	case wav.SampleRate < ReferenceVoiceMinSampleRate:
+		return fmt.Errorf("%w: sample rate %d Hz is below %d Hz",
+			ErrInvalidReferenceVoice, wav.SampleRate, ReferenceVoiceMinSampleRate)
+	case duration < ReferenceVoiceMinDuration:
+		return fmt.Errorf("%w: %v of audio is shorter than %v", ErrInvalidReferenceVoice, duration, ReferenceVoiceMinDuration)
+	case duration > ReferenceVoiceMaxDuration:
+		return fmt.Errorf("%w: %v of audio is longer than %v", ErrInvalidReferenceVoice, duration, ReferenceVoiceMaxDuration)
+	}
+	return nil
+}
+
+// ValidateReferenceVoice validates ReferenceVoiceWav with ValidateReferenceVoiceWAV when it is set,
+// for references that are local files. It is not part of Validate, as the reference may also name
+// a file known to the backend only.
+func (r CreateSpeechRequest) ValidateReferenceVoice() error {
+	if r.ReferenceVoiceWav == "" {
+		return nil
+	}
+	return ValidateReferenceVoiceWAV(r.ReferenceVoiceWav)
+}
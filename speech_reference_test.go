@@ -0,0 +1,44 @@
+package openai_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestValidateReferenceVoiceWAV(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		checks.NoError(t, os.WriteFile(path, data, 0o600), "WriteFile error")
+		return path
+	}
+	wavOf := func(sampleRate int, d time.Duration) []byte {
+		return testWAV(sampleRate, 1, make([]int16, int(d.Seconds()*float64(sampleRate))))
+	}
+
+	valid := write("valid.wav", wavOf(24000, 5*time.Second))
+	checks.NoError(t, openai.ValidateReferenceVoiceWAV(valid), "valid reference rejected")
+	request := openai.CreateSpeechRequest{ReferenceVoiceWav: valid}
+	checks.NoError(t, request.ValidateReferenceVoice(), "valid reference rejected")
+	checks.NoError(t, openai.CreateSpeechRequest{}.ValidateReferenceVoice(), "no reference rejected")
+
+	invalid := map[string][]byte{
+		"short.wav":   wavOf(24000, time.Second),
+		"long.wav":    wavOf(16000, 61*time.Second),
+		"lowrate.wav": silentWAV(5 * time.Second),
+		"voice.mp3":   []byte("ID3 not a wav"),
+		"broken.wav":  []byte("RIFF\x00\x00\x00\x00WAVE"),
+	}
+	for name, data := range invalid {
+		err := openai.ValidateReferenceVoiceWAV(write(name, data))
+		checks.ErrorIs(t, err, openai.ErrInvalidReferenceVoice, name+" should be rejected")
+	}
+
+	err := openai.ValidateReferenceVoiceWAV(filepath.Join(dir, "missing.wav"))
+	checks.ErrorIs(t, err, os.ErrNotExist, "missing reference should fail")
+}
@@ -0,0 +1,116 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+)
+
+// Defaults of the speech API for raw PCM output, see CreateSpeechRequest.
+const (
+	defaultSpeechSampleRate = 24000
+	speechPCMSampleSize     = 2 // 16-bit samples
+)
+
+// SpeechResponse is a speech synthesis result, a friendlier alternative to the RawResponse of
+// CreateSpeech. Its audio is read either with Bytes or with WriteTo; Bytes keeps the audio in
+// memory so that it can be called again and followed by WriteTo.
+type SpeechResponse struct {
+	Format SpeechResponseFormat // format of the audio, as requested or announced by the response
+
+	body       io.ReadCloser
+	data       []byte
+	buffered   bool
+	sampleRate int
+	channels   int
+
+	httpHeader
+}
+
+// CreateSpeechTyped synthesizes speech like CreateSpeech and returns it as a SpeechResponse. The
+// caller must Close the response unless the audio is consumed with Bytes or WriteTo.
+func (c *Client) CreateSpeechTyped(ctx context.Context, request CreateSpeechRequest) (*SpeechResponse, error) {
+	response, err := c.CreateSpeech(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &SpeechResponse{
+		Format:     speechFormatOrDefault(request, response),
+		body:       response.ReadCloser,
+		sampleRate: request.SampleRate,
+		channels:   request.Channel,
+		httpHeader: response.httpHeader,
+	}, nil
+}
+
+// Bytes reads the whole audio and closes the response body.
+func (r *SpeechResponse) Bytes() ([]byte, error) {
+	if r.buffered {
+		return r.data, nil
+	}
+	data, err := io.ReadAll(r.body)
+	if closeErr := r.body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.data, r.buffered = data, true
+	return data, nil
+}
+
+// WriteTo implements io.WriterTo: it streams the audio to w, without buffering it unless Bytes was
+// called before, and closes the response body.
+func (r *SpeechResponse) WriteTo(w io.Writer) (int64, error) {
+	if r.buffered {
+		return io.Copy(w, bytes.NewReader(r.data))
+	}
+	n, err := io.Copy(w, r.body)
+	if closeErr := r.body.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// Duration returns the playback duration of WAV and raw PCM audio, reading the audio with Bytes
+// when needed. PCM audio is assumed to hold 16-bit samples at the SampleRate and Channel of the
+// request, 24kHz mono by default. It reports false for compressed formats and unreadable audio.
+func (r *SpeechResponse) Duration() (time.Duration, bool) {
+	switch r.Format { //nolint:exhaustive // compressed formats need a decoder
+	case SpeechResponseFormatWav:
+		data, err := r.Bytes()
+		if err != nil {
+			return 0, false
+		}
+		wav, err := parseWAV(data)
+		if err != nil {
+			return 0, false
+		}
+		return wav.duration(), true
+	case SpeechResponseFormatPcm:
+		data, err := r.Bytes()
+		if err != nil {
+			return 0, false
+		}
+		sampleRate, channels := r.sampleRate, r.channels
+		if sampleRate <= 0 {
+			sampleRate = defaultSpeechSampleRate
+		}
+		if channels <= 0 {
+			channels = 1
+		}
+		frames := len(data) / (channels * speechPCMSampleSize)
+		return time.Duration(frames) * time.Second / time.Duration(sampleRate), true
+	default:
+		return 0, false
+	}
+}
+
+// Close releases the response body. It is safe to call after Bytes or WriteTo.
+func (r *SpeechResponse) Close() error {
+	if r.buffered {
+		return nil
+	}
+	return r.body.Close()
+}
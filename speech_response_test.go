@@ -0,0 +1,78 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateSpeechTyped(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	audio := testWAV(8000, 1, make([]int16, 4000))
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Header().Set("X-Request-Id", "req_123")
+		_, _ = w.Write(audio)
+	})
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy, Input: "Hello!"}
+
+	response, err := client.CreateSpeechTyped(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechTyped error")
+	defer response.Close()
+	if response.Format != openai.SpeechResponseFormatWav || response.Header().Get("X-Request-Id") != "req_123" {
+		t.Errorf("unexpected format %q or headers %v", response.Format, response.Header())
+	}
+	data, err := response.Bytes()
+	checks.NoError(t, err, "Bytes error")
+	if !bytes.Equal(data, audio) {
+		t.Errorf("expected %d bytes of audio, got %d", len(audio), len(data))
+	}
+	if duration, ok := response.Duration(); !ok || duration != time.Second/2 {
+		t.Errorf("expected a duration of 500ms, got %v, %v", duration, ok)
+	}
+	// The audio stays available after Bytes.
+	var out bytes.Buffer
+	n, err := response.WriteTo(&out)
+	checks.NoError(t, err, "WriteTo error")
+	if n != int64(len(audio)) || !bytes.Equal(out.Bytes(), audio) {
+		t.Errorf("expected WriteTo to replay the audio, got %d bytes", n)
+	}
+
+	request.ResponseFormat = openai.SpeechResponseFormatMp3
+	response, err = client.CreateSpeechTyped(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechTyped error")
+	out.Reset()
+	_, err = response.WriteTo(&out)
+	checks.NoError(t, err, "WriteTo error")
+	if !bytes.Equal(out.Bytes(), audio) {
+		t.Errorf("expected WriteTo to stream the audio, got %d bytes", out.Len())
+	}
+	if _, ok := response.Duration(); ok {
+		t.Error("expected no duration for mp3")
+	}
+}
+
+func TestSpeechResponsePCMDuration(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(make([]byte, 48000)) // one second of 24kHz 16-bit mono
+	})
+	response, err := client.CreateSpeechTyped(context.Background(), openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Voice:          openai.VoiceAlloy,
+		Input:          "Hello!",
+		ResponseFormat: openai.SpeechResponseFormatPcm,
+	})
+	checks.NoError(t, err, "CreateSpeechTyped error")
+	defer response.Close()
+	if duration, ok := response.Duration(); !ok || duration != time.Second {
+		t.Errorf("expected a duration of 1s, got %v, %v", duration, ok)
+	}
+}
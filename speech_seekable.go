@@ -0,0 +1,84 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SeekableSpeechMemoryLimit is the largest speech result CreateSpeechSeekable keeps in memory.
+// Larger results are spooled to a temporary file instead, trading disk I/O for a bounded memory
+// footprint; at the default 128 kbps mp3 bitrate 16 MiB is roughly 17 minutes of audio.
+const SeekableSpeechMemoryLimit = 16 << 20
+
+// SeekableSpeech is a fully downloaded speech result that can be read from any position.
+// Close releases the temporary file backing large results.
+type SeekableSpeech struct {
+	io.ReadSeeker
+
+	file *os.File
+
+	httpHeader
+}
+
+// Close removes the temporary file, if any. It is safe to call more than once.
+func (s *SeekableSpeech) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	file := s.file
+	s.file = nil
+	closeErr := file.Close()
+	if err := os.Remove(file.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// CreateSpeechSeekable — generates speech and buffers the whole result so it can be scrubbed,
+// e.g. by a media player. Results up to SeekableSpeechMemoryLimit bytes are kept in memory, larger
+// ones in a temporary file that is removed by Close.
+func (c *Client) CreateSpeechSeekable(ctx context.Context, request CreateSpeechRequest) (*SeekableSpeech, error) {
+	response, err := c.CreateSpeech(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Close()
+
+	speech := &SeekableSpeech{httpHeader: response.httpHeader}
+	var head bytes.Buffer
+	n, err := io.CopyN(&head, response, SeekableSpeechMemoryLimit+1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if n <= SeekableSpeechMemoryLimit {
+		speech.ReadSeeker = bytes.NewReader(head.Bytes())
+		return speech, nil
+	}
+
+	file, err := os.CreateTemp("", "speech-*."+string(speechFormatOrDefault(request, response)))
+	if err != nil {
+		return nil, fmt.Errorf("creating speech buffer file: %w", err)
+	}
+	speech.ReadSeeker = file
+	speech.file = file
+	if _, err = io.Copy(file, io.MultiReader(&head, response)); err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = speech.Close()
+		return nil, err
+	}
+	return speech, nil
+}
+
+// speechFormatOrDefault returns the requested format, or the one announced by the response.
+func speechFormatOrDefault(request CreateSpeechRequest, response RawResponse) SpeechResponseFormat {
+	if request.ResponseFormat != "" {
+		return request.ResponseFormat
+	}
+	return speechFormatFromContentType(response.Header().Get("Content-Type"))
+}
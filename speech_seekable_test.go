@@ -0,0 +1,89 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateSpeechSeekable(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	audio := []byte("0123456789abcdef")
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(audio)
+	})
+
+	speech, err := client.CreateSpeechSeekable(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoError(t, err, "CreateSpeechSeekable error")
+	defer speech.Close()
+
+	_, err = speech.Seek(10, io.SeekStart)
+	checks.NoError(t, err, "Seek error")
+	rest, _ := io.ReadAll(speech)
+	if string(rest) != "abcdef" {
+		t.Fatalf("unexpected bytes after seeking: %q", rest)
+	}
+	_, err = speech.Seek(-6, io.SeekEnd)
+	checks.NoError(t, err, "Seek error")
+	buf := make([]byte, 2)
+	_, _ = io.ReadFull(speech, buf)
+	if string(buf) != "ab" {
+		t.Fatalf("unexpected bytes after seeking from the end: %q", buf)
+	}
+	if speech.Header().Get("Content-Type") != "audio/mpeg" {
+		t.Errorf("response headers not exposed: %v", speech.Header())
+	}
+}
+
+func TestCreateSpeechSeekableLarge(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	audio := bytes.Repeat([]byte("0123456789"), openai.SeekableSpeechMemoryLimit/10+1)
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(audio)
+	})
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	speech, err := client.CreateSpeechSeekable(context.Background(), openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          "Hello!",
+		Voice:          openai.VoiceAlloy,
+		ResponseFormat: openai.SpeechResponseFormatWav,
+	})
+	checks.NoError(t, err, "CreateSpeechSeekable error")
+
+	entries, _ := os.ReadDir(tmp)
+	if len(entries) != 1 {
+		t.Fatalf("expected the result to be spooled to a temporary file, found %d files", len(entries))
+	}
+	end, err := speech.Seek(-3, io.SeekEnd)
+	checks.NoError(t, err, "Seek error")
+	if end != int64(len(audio))-3 {
+		t.Fatalf("unexpected size: %d", end+3)
+	}
+	tail, _ := io.ReadAll(speech)
+	if string(tail) != "789" {
+		t.Fatalf("unexpected tail: %q", tail)
+	}
+
+	checks.NoError(t, speech.Close(), "Close error")
+	checks.NoError(t, speech.Close(), "second Close error")
+	if entries, _ = os.ReadDir(tmp); len(entries) != 0 {
+		t.Fatalf("temporary file not removed: %v", entries)
+	}
+}
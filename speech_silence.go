@@ -0,0 +1,43 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrSilenceUnsupportedFormat = errors.New("silence can only be generated as WAV or raw PCM audio")
+	ErrInvalidSilence           = errors.New("invalid silence parameters")
+)
+
+// silenceBitsPerSample is the sample size of generated silence, the one of the speech endpoint.
+const silenceBitsPerSample = 16
+
+// GenerateSilence returns d of silent audio in format, e.g. to pad between synthesized chunks
+// before concatenating them. Only SpeechResponseFormatWav and SpeechResponseFormatPcm are
+// supported, as 16-bit samples: raw PCM is little-endian like the speech endpoint's, and matches
+// its output at 24000 Hz mono. Other formats fail with ErrSilenceUnsupportedFormat; a negative
+// duration or a non-positive sampleRate or channels fails with ErrInvalidSilence. The duration is
+// rounded down to whole frames.
+func GenerateSilence(format SpeechResponseFormat, d time.Duration, sampleRate, channels int) ([]byte, error) {
+	if format != SpeechResponseFormatWav && format != SpeechResponseFormatPcm {
+		return nil, fmt.Errorf("%w: %q", ErrSilenceUnsupportedFormat, format)
+	}
+	if d < 0 || sampleRate <= 0 || channels <= 0 {
+		return nil, fmt.Errorf("%w: %v at %d Hz with %d channels", ErrInvalidSilence, d, sampleRate, channels)
+	}
+
+	silence := wavAudio{
+		AudioFormat:   wavFormatPCM,
+		Channels:      channels,
+		SampleRate:    sampleRate,
+		BitsPerSample: silenceBitsPerSample,
+	}
+	frames := int64(d) * int64(sampleRate) / int64(time.Second)
+	silence.Data = make([]byte, int(frames)*silence.blockAlign())
+	if format == SpeechResponseFormatPcm {
+		return silence.Data, nil
+	}
+	return silence.encode(), nil
+}
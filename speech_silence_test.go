@@ -0,0 +1,33 @@
+package openai_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestGenerateSilence(t *testing.T) {
+	pcm, err := openai.GenerateSilence(openai.SpeechResponseFormatPcm, 500*time.Millisecond, 24000, 1)
+	checks.NoError(t, err, "GenerateSilence error")
+	// 12000 frames of one 16-bit sample.
+	if len(pcm) != 24000 || !bytes.Equal(pcm, make([]byte, 24000)) {
+		t.Errorf("expected 24000 zero bytes, got %d bytes", len(pcm))
+	}
+
+	wav, err := openai.GenerateSilence(openai.SpeechResponseFormatWav, 2*time.Second, 16000, 2)
+	checks.NoError(t, err, "GenerateSilence error")
+	if len(wav) != 44+2*16000*2*2 {
+		t.Errorf("expected a 44 byte header and 128000 bytes of audio, got %d bytes", len(wav))
+	}
+	if !bytes.HasPrefix(wav, []byte("RIFF")) || !bytes.Equal(wav[44:], make([]byte, len(wav)-44)) {
+		t.Error("expected a silent WAV file")
+	}
+
+	_, err = openai.GenerateSilence(openai.SpeechResponseFormatMp3, time.Second, 24000, 1)
+	checks.ErrorIs(t, err, openai.ErrSilenceUnsupportedFormat, "expected mp3 to be rejected")
+	_, err = openai.GenerateSilence(openai.SpeechResponseFormatPcm, time.Second, 0, 1)
+	checks.ErrorIs(t, err, openai.ErrInvalidSilence, "expected a zero sample rate to be rejected")
+}
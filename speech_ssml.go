@@ -0,0 +1,81 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSSMLMarker = errors.New("invalid SSML marker")
+)
+
+var ssmlEmphasisLevels = map[string]bool{
+	"strong":   true,
+	"moderate": true,
+	"reduced":  true,
+}
+
+// TextToSSML converts plain text with inline markers into an SSML document.
+// Supported markers are:
+//
+//	[pause=500ms]                 a pause of any positive time.ParseDuration value
+//	[emphasis]...[/emphasis]      emphasized text
+//	[emphasis=strong]...          emphasis with a level: strong, moderate or reduced
+//
+// The text is XML-escaped. Unknown or malformed markers, including unbalanced
+// emphasis, return ErrInvalidSSMLMarker.
+func TextToSSML(input string) (string, error) {
+	var out bytes.Buffer
+	out.WriteString("<speak>")
+
+	openEmphasis := 0
+	for input != "" {
+		start := strings.IndexByte(input, '[')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(input[start:], ']')
+		if end < 0 {
+			return "", fmt.Errorf("%w: unterminated %q", ErrInvalidSSMLMarker, input[start:])
+		}
+		end += start
+		_ = xml.EscapeText(&out, []byte(input[:start]))
+
+		marker := input[start+1 : end]
+		name, value, _ := strings.Cut(marker, "=")
+		switch {
+		case name == "pause":
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return "", fmt.Errorf("%w: [%s] needs a positive duration", ErrInvalidSSMLMarker, marker)
+			}
+			fmt.Fprintf(&out, `<break time="%dms"/>`, d.Milliseconds())
+		case name == "emphasis" && marker == "emphasis":
+			out.WriteString("<emphasis>")
+			openEmphasis++
+		case name == "emphasis" && ssmlEmphasisLevels[value]:
+			fmt.Fprintf(&out, `<emphasis level="%s">`, value)
+			openEmphasis++
+		case marker == "/emphasis":
+			if openEmphasis == 0 {
+				return "", fmt.Errorf("%w: [/emphasis] without [emphasis]", ErrInvalidSSMLMarker)
+			}
+			out.WriteString("</emphasis>")
+			openEmphasis--
+		default:
+			return "", fmt.Errorf("%w: [%s]", ErrInvalidSSMLMarker, marker)
+		}
+		input = input[end+1:]
+	}
+	if openEmphasis > 0 {
+		return "", fmt.Errorf("%w: unclosed [emphasis]", ErrInvalidSSMLMarker)
+	}
+
+	_ = xml.EscapeText(&out, []byte(input))
+	out.WriteString("</speak>")
+	return out.String(), nil
+}
@@ -0,0 +1,44 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestTextToSSML(t *testing.T) {
+	testcases := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello", "<speak>Hello</speak>"},
+		{"Wait[pause=500ms] for it", `<speak>Wait<break time="500ms"/> for it</speak>`},
+		{"[pause=1.5s]", `<speak><break time="1500ms"/></speak>`},
+		{"This is [emphasis]important[/emphasis].", "<speak>This is <emphasis>important</emphasis>.</speak>"},
+		{"[emphasis=strong]Stop![/emphasis]", `<speak><emphasis level="strong">Stop!</emphasis></speak>`},
+		{"Tom & Jerry <3", "<speak>Tom &amp; Jerry &lt;3</speak>"},
+	}
+	for _, tc := range testcases {
+		got, err := openai.TextToSSML(tc.input)
+		checks.NoError(t, err, "TextToSSML error")
+		if got != tc.expected {
+			t.Errorf("TextToSSML(%q): expected %q, got %q", tc.input, tc.expected, got)
+		}
+	}
+}
+
+func TestTextToSSMLInvalidMarkers(t *testing.T) {
+	for _, input := range []string{
+		"[whisper]psst",
+		"[pause=soon]",
+		"[pause=-1s]",
+		"[emphasis=loud]hey[/emphasis]",
+		"[emphasis]never closed",
+		"closed[/emphasis]",
+		"unterminated [pause=1s",
+	} {
+		_, err := openai.TextToSSML(input)
+		checks.ErrorIs(t, err, openai.ErrInvalidSSMLMarker, "expected ErrInvalidSSMLMarker for "+input)
+	}
+}
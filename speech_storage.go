@@ -0,0 +1,37 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"path"
+)
+
+// SpeechStorage is a blob store synthesized speech can be written to, e.g. an object storage
+// bucket served over HTTP.
+type SpeechStorage interface {
+	// Put stores everything read from r under key and returns a URL the browser can play it from.
+	Put(key string, r io.Reader, contentType string) (url string, err error)
+}
+
+// CreateSpeechToStorage synthesizes speech and streams it into storage under key, without buffering
+// the audio in memory, and returns the URL reported by the storage. As in CreateSpeechToFile, the
+// extension of the audio format is appended to a key without one. The audio is stored with the
+// MIME type of its format.
+func (c *Client) CreateSpeechToStorage(
+	ctx context.Context,
+	request CreateSpeechRequest,
+	storage SpeechStorage,
+	key string,
+) (url string, err error) {
+	response, err := c.CreateSpeech(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Close()
+
+	format := speechFormatOrDefault(request, response)
+	if path.Ext(key) == "" {
+		key += format.Extension()
+	}
+	return storage.Put(key, response, format.MIMEType())
+}
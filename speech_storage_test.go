@@ -0,0 +1,61 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// memorySpeechStorage is an in-memory openai.SpeechStorage.
+type memorySpeechStorage struct {
+	blobs        map[string]string
+	contentTypes map[string]string
+}
+
+func (s *memorySpeechStorage) Put(key string, r io.Reader, contentType string) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.blobs[key] = string(data)
+	s.contentTypes[key] = contentType
+	return "https://cdn.example.com/" + key, nil
+}
+
+func TestCreateSpeechToStorage(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		_, _ = w.Write([]byte("RIFF audio"))
+	})
+	storage := &memorySpeechStorage{blobs: map[string]string{}, contentTypes: map[string]string{}}
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy, Input: "Hello!"}
+
+	url, err := client.CreateSpeechToStorage(context.Background(), request, storage, "greetings/hello")
+	checks.NoError(t, err, "CreateSpeechToStorage error")
+	if url != "https://cdn.example.com/greetings/hello.wav" {
+		t.Errorf("unexpected URL %q", url)
+	}
+	if storage.blobs["greetings/hello.wav"] != "RIFF audio" || storage.contentTypes["greetings/hello.wav"] != "audio/wav" {
+		t.Errorf("unexpected stored audio %q (%q)", storage.blobs, storage.contentTypes)
+	}
+
+	request.ResponseFormat = openai.SpeechResponseFormatMp3
+	_, err = client.CreateSpeechToStorage(context.Background(), request, storage, "greetings/hello.audio")
+	checks.NoError(t, err, "CreateSpeechToStorage error")
+	if storage.contentTypes["greetings/hello.audio"] != "audio/mpeg" {
+		t.Errorf("expected an explicit extension to be kept, got %q", storage.contentTypes)
+	}
+
+	request.Input = ""
+	_, err = client.CreateSpeechToStorage(context.Background(), request, storage, "empty")
+	if !errors.Is(err, openai.ErrEmptySpeechInput) || len(storage.blobs) != 2 {
+		t.Errorf("expected nothing to be stored for an invalid request, got %v", err)
+	}
+}
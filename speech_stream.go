@@ -0,0 +1,232 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SpeechStream is the audio body of a streaming speech synthesis.
+//
+// Closing the stream cancels the underlying HTTP request, so the server stops
+// generating audio even when the body has not been fully read.
+type SpeechStream struct {
+	RawResponse
+
+	cancel context.CancelFunc
+
+	// Reconnection state, see CreateSpeechRequest.MaxReconnects.
+	ctx        context.Context
+	client     *Client
+	request    CreateSpeechRequest
+	received   int64
+	reconnects int
+
+	// events decodes event stream responses when request.OnBoundary is set.
+	events *speechEventReader
+}
+
+// CreateSpeechStream — API call to synthesize speech, returning the audio as it is
+// being generated. The caller must Close the returned stream; closing it early aborts the request.
+// Unlike CreateSpeech, it accepts Stream for models that stream delta events, passing them through
+// unless request.OnBoundary is set.
+// When request.MaxReconnects is set, a dropped connection is resumed transparently.
+func (c *Client) CreateSpeechStream(ctx context.Context, request CreateSpeechRequest) (*SpeechStream, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkKnownModel(string(request.Model)); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	response, err := c.sendSpeechStreamRequest(ctx, request, 0)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream := &SpeechStream{
+		RawResponse: response,
+		cancel:      cancel,
+		ctx:         ctx,
+		client:      c,
+		request:     request,
+	}
+	if request.OnBoundary != nil && isEventStream(response.Header().Get("Content-Type")) {
+		stream.events = newSpeechEventReader(speechBodyReader{stream}, request.OnBoundary)
+	}
+	return stream, nil
+}
+
+// sendSpeechStreamRequest sends the speech request, asking for the audio from offset on when
+// offset is positive.
+func (c *Client) sendSpeechStreamRequest(
+	ctx context.Context,
+	request CreateSpeechRequest,
+	offset int64,
+) (RawResponse, error) {
+	req, err := c.newRequest(
+		ctx,
+		http.MethodPost,
+		c.fullURL("/audio/speech", withModel(string(request.Model))),
+		withBody(request),
+		withContentType("application/json"),
+		withAudioEndpoint("/audio/speech"),
+	)
+	if err != nil {
+		return RawResponse{}, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return c.sendRequestRaw(req)
+}
+
+// Read reads the audio, reconnecting when the connection drops as allowed by MaxReconnects.
+// Event streams are decoded as described in CreateSpeechRequest.OnBoundary.
+func (s *SpeechStream) Read(p []byte) (int, error) {
+	if s.events != nil {
+		return s.events.Read(p)
+	}
+	return s.readBody(p)
+}
+
+// speechBodyReader reads the response body of a SpeechStream, undecoded.
+type speechBodyReader struct {
+	stream *SpeechStream
+}
+
+func (r speechBodyReader) Read(p []byte) (int, error) {
+	return r.stream.readBody(p)
+}
+
+// readBody reads the response body, reconnecting when the connection drops.
+func (s *SpeechStream) readBody(p []byte) (int, error) {
+	n, err := s.RawResponse.Read(p)
+	s.received += int64(n)
+	if err == nil || errors.Is(err, io.EOF) || s.reconnects >= s.request.MaxReconnects || s.ctx.Err() != nil {
+		return n, err
+	}
+	if resumeErr := s.resume(err); resumeErr != nil {
+		return n, resumeErr
+	}
+	return n, nil
+}
+
+// WriteTo implements io.WriterTo like RawResponse.WriteTo, reconnecting as Read does.
+func (s *SpeechStream) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.Copy(w, struct{ io.Reader }{s})
+	if closeErr := s.RawResponse.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// resume replaces the dropped response body with the rest of the audio.
+func (s *SpeechStream) resume(readErr error) error {
+	s.reconnects++
+	_ = s.RawResponse.Close()
+	response, err := s.client.sendSpeechStreamRequest(s.ctx, s.request, s.received)
+	if err != nil {
+		return fmt.Errorf("reconnecting after %v: %w", readErr, err) //nolint:errorlint // keep the request error matchable
+	}
+	if !strings.HasPrefix(response.Header().Get("Content-Range"), fmt.Sprintf("bytes %d-", s.received)) {
+		_ = response.Close()
+		return fmt.Errorf( //nolint:errorlint // keep the sentinel matchable
+			"%w: reconnecting after %v", ErrSpeechResumeUnsupported, readErr)
+	}
+	s.RawResponse.ReadCloser = response.ReadCloser
+	return nil
+}
+
+// Close cancels the request and releases the connection.
+func (s *SpeechStream) Close() error {
+	s.cancel()
+	return s.RawResponse.Close()
+}
+
+// SpeechWriterTransform decorates the destination of a streamed speech, for instance to write a
+// WAV header in front of raw PCM. When the returned writer implements io.Closer it is closed at
+// the end of the stream, otherwise it is flushed if it has a Flush() error method.
+// The destination writer itself is never closed.
+type SpeechWriterTransform func(io.Writer) io.Writer
+
+// CreateSpeechStreamTo synthesizes speech and streams the audio into w as it arrives, without
+// buffering it. transform is optional. It returns the number of bytes read from the response.
+func (c *Client) CreateSpeechStreamTo(
+	ctx context.Context,
+	request CreateSpeechRequest,
+	w io.Writer,
+	transform SpeechWriterTransform,
+) (written int64, err error) {
+	stream, err := c.CreateSpeechStream(ctx, request)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.Close()
+
+	dst := w
+	if transform != nil {
+		dst = transform(w)
+	}
+	written, err = io.Copy(dst, stream)
+	if finishErr := finishSpeechWriter(dst, w); err == nil {
+		err = finishErr
+	}
+	return written, err
+}
+
+// speechChunkSize is the size of the buffer CreateSpeechStreamFunc reads the audio into.
+const speechChunkSize = 32 * 1024
+
+// CreateSpeechStreamFunc synthesizes speech and calls onChunk with every chunk of audio as it is
+// read, e.g. to drive a level meter. The chunk is only valid during the call. An error returned by
+// onChunk aborts the stream and is returned as is; the stream is also aborted when ctx is done.
+func (c *Client) CreateSpeechStreamFunc(
+	ctx context.Context,
+	request CreateSpeechRequest,
+	onChunk func([]byte) error,
+) error {
+	stream, err := c.CreateSpeechStream(ctx, request)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	buf := make([]byte, speechChunkSize)
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		n, readErr := stream.Read(buf)
+		if n > 0 {
+			if err = onChunk(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// finishSpeechWriter closes or flushes a transformed writer at the end of a stream.
+func finishSpeechWriter(dst, original io.Writer) error {
+	if dst == original {
+		return nil
+	}
+	switch writer := dst.(type) {
+	case io.Closer:
+		return writer.Close()
+	case interface{ Flush() error }:
+		return writer.Flush()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,258 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateSpeechStream(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("chunk1chunk2"))
+	})
+
+	stream, err := client.CreateSpeechStream(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoErrorF(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+
+	audio, err := io.ReadAll(stream)
+	checks.NoError(t, err, "ReadAll error")
+	if string(audio) != "chunk1chunk2" {
+		t.Errorf("unexpected audio %q", audio)
+	}
+	if stream.Header().Get("Content-Type") != "audio/mpeg" {
+		t.Errorf("unexpected headers %v", stream.Header())
+	}
+}
+
+func TestSpeechStreamCloseAbortsRequest(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	aborted := make(chan struct{})
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("chunk1"))
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		<-r.Context().Done()
+		close(aborted)
+	})
+
+	stream, err := client.CreateSpeechStream(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoErrorF(t, err, "CreateSpeechStream error")
+
+	buf := make([]byte, len("chunk1"))
+	_, err = io.ReadFull(stream, buf)
+	checks.NoErrorF(t, err, "ReadFull error")
+
+	checks.NoError(t, stream.Close(), "Close error")
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("closing the stream did not abort the request")
+	}
+}
+
+// upperWriter upper-cases bytes before writing them and records when it is closed.
+type upperWriter struct {
+	w      io.Writer
+	closed bool
+}
+
+func (u *upperWriter) Write(p []byte) (int, error) {
+	return u.w.Write(bytes.ToUpper(p))
+}
+
+func (u *upperWriter) Close() error {
+	u.closed = true
+	return nil
+}
+
+func TestCreateSpeechStreamTo(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("pcm-"))
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		_, _ = w.Write([]byte("samples"))
+	})
+
+	request := openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          "Hello!",
+		Voice:          openai.VoiceAlloy,
+		ResponseFormat: openai.SpeechResponseFormatPcm,
+	}
+
+	var out bytes.Buffer
+	var transformed *upperWriter
+	written, err := client.CreateSpeechStreamTo(context.Background(), request, &out, func(w io.Writer) io.Writer {
+		transformed = &upperWriter{w: w}
+		return transformed
+	})
+	checks.NoErrorF(t, err, "CreateSpeechStreamTo error")
+	if out.String() != "PCM-SAMPLES" || written != int64(len("pcm-samples")) {
+		t.Errorf("unexpected output %q (%d bytes)", out.String(), written)
+	}
+	if !transformed.closed {
+		t.Error("transform writer was not closed at the end of the stream")
+	}
+
+	out.Reset()
+	_, err = client.CreateSpeechStreamTo(context.Background(), request, &out, nil)
+	checks.NoErrorF(t, err, "CreateSpeechStreamTo without transform error")
+	if out.String() != "pcm-samples" {
+		t.Errorf("unexpected untransformed output %q", out.String())
+	}
+}
+
+func TestSpeechStreamRequiresStreamAPI(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	calls := 0
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"type\":\"speech.audio.delta\"}\n\n"))
+	})
+	request := openai.CreateSpeechRequest{
+		Model:  openai.TTSModelCanary,
+		Input:  "Hello!",
+		Voice:  openai.VoiceAlloy,
+		Stream: true,
+	}
+
+	_, err := client.CreateSpeech(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrSpeechStreamRequiresStreamAPI, "expected the blocking call to be rejected")
+	checks.ErrorIs(t, request.Validate(), openai.ErrSpeechStreamRequiresStreamAPI, "Validate error")
+	if calls != 0 {
+		t.Fatalf("expected no request to be sent, got %d", calls)
+	}
+
+	stream, err := client.CreateSpeechStream(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+	events, err := io.ReadAll(stream)
+	checks.NoError(t, err, "ReadAll error")
+	if !bytes.Contains(events, []byte("speech.audio.delta")) {
+		t.Errorf("expected the delta events to be passed through, got %q", events)
+	}
+
+	request.Model = openai.TTSModel1
+	checks.NoError(t, request.Validate(), "models streaming plain audio accept Stream")
+}
+
+func TestCreateSpeechStreamFunc(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		for _, chunk := range []string{"one-", "two-", "three"} {
+			_, _ = w.Write([]byte(chunk))
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+		}
+	})
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: "Hello!", Voice: openai.VoiceAlloy}
+
+	var received bytes.Buffer
+	err := client.CreateSpeechStreamFunc(context.Background(), request, func(chunk []byte) error {
+		received.Write(chunk)
+		return nil
+	})
+	checks.NoError(t, err, "CreateSpeechStreamFunc error")
+	if received.String() != "one-two-three" {
+		t.Errorf("expected every byte in order, got %q", received.String())
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = client.CreateSpeechStreamFunc(context.Background(), request, func([]byte) error {
+		calls++
+		return errStop
+	})
+	checks.ErrorIs(t, err, errStop, "expected the callback error to abort the stream")
+	if calls != 1 {
+		t.Errorf("expected the stream to stop after the first chunk, got %d calls", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.CreateSpeechStreamFunc(ctx, request, func([]byte) error { return nil })
+	checks.ErrorIs(t, err, context.Canceled, "expected a canceled context to abort the stream")
+}
+
+func TestSpeechStreamReconnect(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	const audio = "chunk1chunk2"
+	supportsRange := true
+	var ranges []string
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if r.Header.Get("Range") == "" {
+			// Drop the connection halfway through the audio.
+			w.Header().Set("Content-Length", strconv.Itoa(len(audio)))
+			_, _ = w.Write([]byte(audio[:6]))
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			panic(http.ErrAbortHandler)
+		}
+		if supportsRange {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 6-11/%d", len(audio)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		_, _ = w.Write([]byte(audio[6:]))
+	})
+	request := openai.CreateSpeechRequest{
+		Model:         openai.TTSModel1,
+		Input:         "Hello!",
+		Voice:         openai.VoiceAlloy,
+		MaxReconnects: 1,
+	}
+
+	var out bytes.Buffer
+	_, err := client.CreateSpeechStreamTo(context.Background(), request, &out, nil)
+	checks.NoError(t, err, "CreateSpeechStreamTo error")
+	if out.String() != audio {
+		t.Errorf("expected the resumed audio %q, got %q", audio, out.String())
+	}
+	if len(ranges) != 2 || ranges[1] != "bytes=6-" {
+		t.Errorf("unexpected Range headers %q", ranges)
+	}
+
+	supportsRange = false
+	_, err = client.CreateSpeechStreamTo(context.Background(), request, io.Discard, nil)
+	checks.ErrorIs(t, err, openai.ErrSpeechResumeUnsupported, "expected a backend without ranges to fail")
+
+	request.MaxReconnects = 0
+	_, err = client.CreateSpeechStreamTo(context.Background(), request, io.Discard, nil)
+	checks.HasError(t, err, "expected the drop to be reported without reconnects")
+	if errors.Is(err, openai.ErrSpeechResumeUnsupported) {
+		t.Errorf("expected the read error itself, got %v", err)
+	}
+}
@@ -3,12 +3,14 @@ package openai_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/sashabaranov/go-openai"
@@ -97,7 +99,7 @@ func TestSpeechIntegration(t *testing.T) {
 
 func TestCreateSpeechRequest(t *testing.T) {
 	req := openai.CreateSpeechRequest{
-		ReferWavPathSovits: map[string]openai.FloatFrac{
+		TimberWeights: map[string]openai.FloatFrac{
 			"test": 0,
 		},
 	}
@@ -105,3 +107,260 @@ func TestCreateSpeechRequest(t *testing.T) {
 	body, _ := json.Marshal(req)
 	fmt.Println(body)
 }
+
+func TestCreateSpeechToFile(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateSpeechRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "failed to parse request body", http.StatusBadRequest)
+			return
+		}
+		contentType := "audio/mpeg"
+		if req.ResponseFormat == openai.SpeechResponseFormatWav || req.Input == "wav" {
+			contentType = "audio/wav"
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte("audio"))
+	})
+
+	testcases := []struct {
+		name         string
+		path         string
+		input        string
+		format       openai.SpeechResponseFormat
+		expectedPath string
+		warnings     int
+	}{
+		{"extension from content type", "speech", "wav", "", "speech.wav", 0},
+		{"default extension", "speech", "mp3", "", "speech.mp3", 0},
+		{"extension from response format", "speech", "flac", openai.SpeechResponseFormatFlac, "speech.flac", 0},
+		{"matching extension", "speech.wav", "wav", openai.SpeechResponseFormatWav, "speech.wav", 0},
+		{"mismatched extension", "speech.wav", "mp3", "", "speech.wav", 1},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file, err := client.CreateSpeechToFile(context.Background(), openai.CreateSpeechRequest{
+				Model:          openai.TTSModel1,
+				Input:          tc.input,
+				Voice:          openai.VoiceAlloy,
+				ResponseFormat: tc.format,
+			}, filepath.Join(dir, tc.path))
+			checks.NoError(t, err, "CreateSpeechToFile error")
+
+			if file.Path != filepath.Join(dir, tc.expectedPath) {
+				t.Errorf("expected path %s, got %s", tc.expectedPath, file.Path)
+			}
+			if len(file.Warnings) != tc.warnings {
+				t.Errorf("expected %d warnings, got %v", tc.warnings, file.Warnings)
+			}
+			content, err := os.ReadFile(file.Path)
+			checks.NoError(t, err, "ReadFile error")
+			if string(content) != "audio" {
+				t.Errorf("unexpected file content %q", content)
+			}
+		})
+	}
+}
+
+func TestCreateSpeechToFileTruncated(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		// The connection drops after half of the announced audio.
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("Content-Length", "10")
+		_, _ = w.Write([]byte("audio"))
+	})
+
+	path := filepath.Join(t.TempDir(), "speech.mp3")
+	file, err := client.CreateSpeechToFile(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Hello!",
+		Voice: openai.VoiceAlloy,
+	}, path)
+	checks.HasError(t, err, "expected the truncated body to fail")
+	if file.Path != "" {
+		t.Errorf("expected no path on failure, got %q", file.Path)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("expected the partial file to be removed, got %v", statErr)
+	}
+}
+
+func TestCreateSpeechRequestPronunciations(t *testing.T) {
+	req := openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: "Welcome to Nguyen's",
+		Voice: openai.VoiceAlloy,
+	}
+	body, err := json.Marshal(req)
+	checks.NoError(t, err, "Marshal error")
+	if strings.Contains(string(body), "pronunciations") {
+		t.Fatalf("pronunciations must be omitted when unset: %s", body)
+	}
+
+	req.Pronunciations = map[string]string{"Nguyen": "wɪn"}
+	body, err = json.Marshal(req)
+	checks.NoError(t, err, "Marshal error")
+
+	var params map[string]any
+	checks.NoError(t, json.Unmarshal(body, &params), "Unmarshal error")
+	pronunciations, ok := params["pronunciations"].(map[string]any)
+	if !ok || pronunciations["Nguyen"] != "wɪn" {
+		t.Fatalf("expected pronunciations in body, got %s", body)
+	}
+}
+
+func TestCreateSpeechEmptyInput(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var calls int
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("audio"))
+	})
+
+	for _, input := range []string{"", "   ", "\n\t"} {
+		request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: input, Voice: openai.VoiceAlloy}
+		checks.ErrorIs(t, request.Validate(), openai.ErrEmptySpeechInput, "Validate should reject empty input")
+
+		_, err := client.CreateSpeech(context.Background(), request)
+		checks.ErrorIs(t, err, openai.ErrEmptySpeechInput, "CreateSpeech should reject empty input")
+		_, err = client.CreateSpeechStream(context.Background(), request)
+		checks.ErrorIs(t, err, openai.ErrEmptySpeechInput, "CreateSpeechStream should reject empty input")
+	}
+	if calls != 0 {
+		t.Fatalf("expected no request to be sent, got %d", calls)
+	}
+}
+
+func TestCreateSpeechRequestMaxInputChars(t *testing.T) {
+	testcases := []struct {
+		name    string
+		request openai.CreateSpeechRequest
+		tooLong bool
+		max     int
+	}{
+		{"tts-1 at limit", openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: strings.Repeat("a", 4096)}, false, 0},
+		{"tts-1 over limit", openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: strings.Repeat("a", 4097)}, true, 4096},
+		{
+			"multibyte characters count once",
+			openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: strings.Repeat("语", 4096)}, false, 0,
+		},
+		{
+			"custom limit",
+			openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: "hello world", MaxInputChars: 5}, true, 5,
+		},
+		{
+			"limit disabled",
+			openai.CreateSpeechRequest{Model: openai.TTSModel1, Input: strings.Repeat("a", 5000), MaxInputChars: -1}, false, 0,
+		},
+		{"unknown model has no default", openai.CreateSpeechRequest{Model: "my-tts", Input: strings.Repeat("a", 9000)}, false, 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.Validate()
+			if !tc.tooLong {
+				checks.NoError(t, err, "Validate error")
+				return
+			}
+			checks.ErrorIs(t, err, openai.ErrInputTooLong, "expected ErrInputTooLong")
+			var tooLong *openai.InputTooLongError
+			if !errors.As(err, &tooLong) || tooLong.Max != tc.max || tooLong.Length != len([]rune(tc.request.Input)) {
+				t.Fatalf("unexpected error details: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateSpeechRequestInstructions(t *testing.T) {
+	testcases := []struct {
+		model     openai.SpeechModel
+		supported bool
+	}{
+		{openai.TTSModel1, false},
+		{openai.TTSModel1HD, false},
+		{openai.TTSModelGPT4oMini, true},
+		{openai.TTSModelCanary, true},
+		{"my-tts", true},
+	}
+	for _, tc := range testcases {
+		t.Run(string(tc.model), func(t *testing.T) {
+			if tc.model.SupportsInstructions() != tc.supported {
+				t.Fatalf("SupportsInstructions() = %v, want %v", !tc.supported, tc.supported)
+			}
+			request := openai.CreateSpeechRequest{Model: tc.model, Input: "Hello!"}
+			checks.NoError(t, request.Validate(), "requests without instructions are always valid")
+
+			request.Instructions = "Speak in a cheerful tone."
+			err := request.Validate()
+			if tc.supported {
+				checks.NoError(t, err, "Validate error")
+			} else {
+				checks.ErrorIs(t, err, openai.ErrSpeechInstructionsNotSupported, "expected instructions to be rejected")
+			}
+		})
+	}
+}
+
+func TestCreateSpeechRequestVoiceCloning(t *testing.T) {
+	testcases := []struct {
+		model     openai.SpeechModel
+		supported bool
+	}{
+		{openai.TTSModel1, false},
+		{openai.TTSModel1HD, false},
+		{openai.TTSModelGPT4oMini, false},
+		{openai.TTSModelCanary, true},
+		{"my-cloning-tts", true},
+	}
+	for _, tc := range testcases {
+		t.Run(string(tc.model), func(t *testing.T) {
+			if tc.model.SupportsVoiceCloning() != tc.supported {
+				t.Fatalf("SupportsVoiceCloning() = %v, want %v", !tc.supported, tc.supported)
+			}
+			requests := []openai.CreateSpeechRequest{
+				{Model: tc.model, Input: "Hello!", ReferenceVoiceWav: "/voices/me.wav"},
+				{Model: tc.model, Input: "Hello!", TimberWeights: map[string]openai.FloatFrac{"alloy": 1}},
+			}
+			for _, request := range requests {
+				err := request.Validate()
+				if tc.supported {
+					checks.NoError(t, err, "Validate error")
+				} else {
+					checks.ErrorIs(t, err, openai.ErrSpeechVoiceCloningNotSupported, "expected voice cloning to be rejected")
+				}
+			}
+		})
+	}
+}
+
+func TestSpeechResponseFormatExtension(t *testing.T) {
+	cases := []struct {
+		format    openai.SpeechResponseFormat
+		extension string
+		mimeType  string
+	}{
+		{openai.SpeechResponseFormatMp3, ".mp3", "audio/mpeg"},
+		{openai.SpeechResponseFormatOpus, ".opus", "audio/opus"},
+		{openai.SpeechResponseFormatAac, ".aac", "audio/aac"},
+		{openai.SpeechResponseFormatFlac, ".flac", "audio/flac"},
+		{openai.SpeechResponseFormatWav, ".wav", "audio/wav"},
+		{openai.SpeechResponseFormatPcm, ".pcm", "audio/pcm"},
+		{"", "", ""},
+		{"ogg", "", ""},
+	}
+	for _, c := range cases {
+		if got := c.format.Extension(); got != c.extension {
+			t.Errorf("%q: expected extension %q, got %q", c.format, c.extension, got)
+		}
+		if got := c.format.MIMEType(); got != c.mimeType {
+			t.Errorf("%q: expected MIME type %q, got %q", c.format, c.mimeType, got)
+		}
+	}
+}
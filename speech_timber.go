@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrUnknownTimberPreset  = errors.New("unknown timber preset")
+	ErrInvalidTimberWeights = errors.New("invalid timber weights")
+)
+
+// TimberPresets is a registry of named voice-fusion presets, each mapping voices to weights.
+// It is safe for concurrent use.
+type TimberPresets struct {
+	mu      sync.RWMutex
+	presets map[string]map[string]FloatFrac
+}
+
+// NewTimberPresets returns an empty preset registry.
+func NewTimberPresets() *TimberPresets {
+	return &TimberPresets{presets: make(map[string]map[string]FloatFrac)}
+}
+
+// Register stores weights under name, replacing any preset with the same name. Weights must not be
+// negative and must not all be zero. The map is copied.
+func (p *TimberPresets) Register(name string, weights map[string]FloatFrac) error {
+	if _, err := sumTimberWeights(weights); err != nil {
+		return fmt.Errorf("preset %q: %w", name, err)
+	}
+	preset := make(map[string]FloatFrac, len(weights))
+	for voice, weight := range weights {
+		preset[voice] = weight
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.presets[name] = preset
+	return nil
+}
+
+// Apply merges the named presets into request.TimberWeights and normalizes the result so the
+// weights sum to 1. Weights already set on the request take part in the merge. When several
+// sources weigh the same voice the weights are summed, so a voice shared by two presets counts
+// for both of them. Presets are combined as registered, use Register with normalized weights to
+// give every preset the same influence.
+func (p *TimberPresets) Apply(request *CreateSpeechRequest, names ...string) error {
+	merged := make(map[string]FloatFrac, len(request.TimberWeights))
+	for voice, weight := range request.TimberWeights {
+		merged[voice] += weight
+	}
+
+	p.mu.RLock()
+	for _, name := range names {
+		preset, ok := p.presets[name]
+		if !ok {
+			p.mu.RUnlock()
+			return fmt.Errorf("%w: %q", ErrUnknownTimberPreset, name)
+		}
+		for voice, weight := range preset {
+			merged[voice] += weight
+		}
+	}
+	p.mu.RUnlock()
+
+	normalized, err := NormalizeTimberWeights(merged)
+	if err != nil {
+		return err
+	}
+	request.TimberWeights = normalized
+	return nil
+}
+
+// NormalizeTimberWeights returns a copy of weights scaled so they sum to 1.
+func NormalizeTimberWeights(weights map[string]FloatFrac) (map[string]FloatFrac, error) {
+	total, err := sumTimberWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+	normalized := make(map[string]FloatFrac, len(weights))
+	for voice, weight := range weights {
+		normalized[voice] = weight / total
+	}
+	return normalized, nil
+}
+
+func sumTimberWeights(weights map[string]FloatFrac) (FloatFrac, error) {
+	var total FloatFrac
+	for voice, weight := range weights {
+		if weight < 0 {
+			return 0, fmt.Errorf("%w: negative weight for %q", ErrInvalidTimberWeights, voice)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("%w: weights sum to zero", ErrInvalidTimberWeights)
+	}
+	return total, nil
+}
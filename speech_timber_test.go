@@ -0,0 +1,51 @@
+package openai_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestTimberPresets(t *testing.T) {
+	presets := openai.NewTimberPresets()
+	checks.NoError(t, presets.Register("warm", map[string]openai.FloatFrac{"alice": 3, "bob": 1}), "Register error")
+	checks.NoError(t, presets.Register("bright", map[string]openai.FloatFrac{"bob": 0.5, "carol": 0.5}), "Register error")
+
+	request := openai.CreateSpeechRequest{Input: "Hello!"}
+	checks.NoError(t, presets.Apply(&request, "warm"), "Apply error")
+	assertTimberWeights(t, request.TimberWeights, map[string]float64{"alice": 0.75, "bob": 0.25})
+
+	// Weights of a voice shared by several sources are summed before normalizing.
+	request = openai.CreateSpeechRequest{Input: "Hello!", TimberWeights: map[string]openai.FloatFrac{"dave": 1}}
+	checks.NoError(t, presets.Apply(&request, "warm", "bright"), "Apply error")
+	assertTimberWeights(t, request.TimberWeights, map[string]float64{
+		"alice": 3.0 / 6, "bob": 1.5 / 6, "carol": 0.5 / 6, "dave": 1.0 / 6,
+	})
+
+	err := presets.Apply(&request, "missing")
+	checks.ErrorIs(t, err, openai.ErrUnknownTimberPreset, "unknown presets should be rejected")
+
+	err = presets.Register("broken", map[string]openai.FloatFrac{"alice": -1, "bob": 2})
+	checks.ErrorIs(t, err, openai.ErrInvalidTimberWeights, "negative weights should be rejected")
+	_, err = openai.NormalizeTimberWeights(map[string]openai.FloatFrac{"alice": 0})
+	checks.ErrorIs(t, err, openai.ErrInvalidTimberWeights, "zero weights cannot be normalized")
+}
+
+func assertTimberWeights(t *testing.T, got map[string]openai.FloatFrac, want map[string]float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d voices, got %v", len(want), got)
+	}
+	var sum float64
+	for voice, weight := range want {
+		if math.Abs(float64(got[voice])-weight) > 1e-9 {
+			t.Errorf("voice %q: expected weight %v, got %v", voice, weight, got[voice])
+		}
+		sum += float64(got[voice])
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("weights sum to %v, want 1", sum)
+	}
+}
@@ -0,0 +1,155 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidWAV = errors.New("invalid WAV audio")
+)
+
+const (
+	wavHeaderSize         = 44
+	wavFormatPCM          = 1
+	wavFormatFloat        = 3
+	wavFormatExtensible   = 0xfffe
+	wavExtensibleFmtSize  = 40
+	wavSubFormatTagOffset = 24
+)
+
+// wavAudio is a decoded RIFF/WAVE file holding interleaved PCM samples.
+type wavAudio struct {
+	AudioFormat   uint16
+	Channels      int
+	SampleRate    int
+	BitsPerSample int
+	Data          []byte
+	DataOffset    int // position of Data in the parsed file
+}
+
+// isWAV reports whether b starts with a RIFF/WAVE header.
+func isWAV(b []byte) bool {
+	return len(b) >= 12 && string(b[0:4]) == "RIFF" && string(b[8:12]) == "WAVE"
+}
+
+// parseWAV decodes the fmt and data chunks of a WAV file, ignoring any other chunk.
+func parseWAV(b []byte) (wav wavAudio, err error) {
+	if !isWAV(b) {
+		return wav, fmt.Errorf("%w: missing RIFF/WAVE header", ErrInvalidWAV)
+	}
+
+	var hasFormat, hasData bool
+	for pos := 12; pos+8 <= len(b); {
+		id := string(b[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(b[pos+4 : pos+8]))
+		pos += 8
+		if size > len(b)-pos {
+			// Streamed WAVs may carry a placeholder size, use what is available.
+			size = len(b) - pos
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return wav, fmt.Errorf("%w: fmt chunk too short", ErrInvalidWAV)
+			}
+			wav.AudioFormat = binary.LittleEndian.Uint16(b[pos:])
+			wav.Channels = int(binary.LittleEndian.Uint16(b[pos+2:]))
+			wav.SampleRate = int(binary.LittleEndian.Uint32(b[pos+4:]))
+			wav.BitsPerSample = int(binary.LittleEndian.Uint16(b[pos+14:]))
+			if wav.AudioFormat == wavFormatExtensible {
+				// WAVE_FORMAT_EXTENSIBLE stores the actual format tag at the start of its SubFormat GUID,
+				// keep that one as encode writes a plain 16 byte fmt chunk.
+				if size < wavExtensibleFmtSize {
+					return wav, fmt.Errorf("%w: extensible fmt chunk too short", ErrInvalidWAV)
+				}
+				wav.AudioFormat = binary.LittleEndian.Uint16(b[pos+wavSubFormatTagOffset:])
+			}
+			hasFormat = true
+		case "data":
+			wav.Data = b[pos : pos+size]
+			wav.DataOffset = pos
+			hasData = true
+		}
+		pos += size + size%2
+	}
+
+	switch {
+	case !hasFormat:
+		return wav, fmt.Errorf("%w: missing fmt chunk", ErrInvalidWAV)
+	case !hasData:
+		return wav, fmt.Errorf("%w: missing data chunk", ErrInvalidWAV)
+	case wav.Channels <= 0 || wav.SampleRate <= 0 || wav.BitsPerSample <= 0 || wav.BitsPerSample%8 != 0:
+		return wav, fmt.Errorf("%w: unsupported format (%d channels, %d Hz, %d bits)",
+			ErrInvalidWAV, wav.Channels, wav.SampleRate, wav.BitsPerSample)
+	}
+	wav.Data = wav.Data[:len(wav.Data)-len(wav.Data)%wav.blockAlign()]
+	return wav, nil
+}
+
+// blockAlign is the size in bytes of one frame, i.e. one sample for every channel.
+func (w wavAudio) blockAlign() int {
+	return w.Channels * w.BitsPerSample / 8
+}
+
+// frames returns the number of sample frames in Data.
+func (w wavAudio) frames() int {
+	return len(w.Data) / w.blockAlign()
+}
+
+// duration returns the playback duration of Data.
+func (w wavAudio) duration() time.Duration {
+	return time.Duration(w.frames()) * time.Second / time.Duration(w.SampleRate)
+}
+
+// slice returns the audio between the given frame indexes.
+func (w wavAudio) slice(startFrame, endFrame int) wavAudio {
+	if endFrame > w.frames() {
+		endFrame = w.frames()
+	}
+	if startFrame > endFrame {
+		startFrame = endFrame
+	}
+	w.Data = w.Data[startFrame*w.blockAlign() : endFrame*w.blockAlign()]
+	return w
+}
+
+// encode returns a canonical 44 byte header WAV file for the audio.
+func (w wavAudio) encode() []byte {
+	audioFormat := w.AudioFormat
+	if audioFormat == 0 {
+		audioFormat = wavFormatPCM
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, wavHeaderSize+len(w.Data)))
+	buf.WriteString("RIFF")
+	_ = binary.Write(buf, binary.LittleEndian, uint32(wavHeaderSize-8+len(w.Data)))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(buf, binary.LittleEndian, uint32(16))
+	_ = binary.Write(buf, binary.LittleEndian, audioFormat)
+	_ = binary.Write(buf, binary.LittleEndian, uint16(w.Channels))
+	_ = binary.Write(buf, binary.LittleEndian, uint32(w.SampleRate))
+	_ = binary.Write(buf, binary.LittleEndian, uint32(w.SampleRate*w.blockAlign()))
+	_ = binary.Write(buf, binary.LittleEndian, uint16(w.blockAlign()))
+	_ = binary.Write(buf, binary.LittleEndian, uint16(w.BitsPerSample))
+	buf.WriteString("data")
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(w.Data)))
+	buf.Write(w.Data)
+	return buf.Bytes()
+}
+
+// channel returns the samples of a single channel as mono audio.
+func (w wavAudio) channel(index int) wavAudio {
+	sampleSize := w.BitsPerSample / 8
+	mono := make([]byte, 0, w.frames()*sampleSize)
+	for frame := 0; frame < w.frames(); frame++ {
+		offset := frame*w.blockAlign() + index*sampleSize
+		mono = append(mono, w.Data[offset:offset+sampleSize]...)
+	}
+	w.Channels = 1
+	w.Data = mono
+	return w
+}
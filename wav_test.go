@@ -0,0 +1,123 @@
+package openai //nolint:testpackage // testing unexported WAV helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWAVRoundTrip(t *testing.T) {
+	wav := wavAudio{
+		Channels:      2,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+		Data:          make([]byte, 8000*4),
+	}
+	for i := range wav.Data {
+		wav.Data[i] = byte(i)
+	}
+
+	encoded := wav.encode()
+	if len(encoded) != wavHeaderSize+len(wav.Data) {
+		t.Fatalf("unexpected encoded size %d", len(encoded))
+	}
+	decoded, err := parseWAV(encoded)
+	if err != nil {
+		t.Fatalf("parseWAV error: %v", err)
+	}
+	if decoded.Channels != 2 || decoded.SampleRate != 8000 || decoded.BitsPerSample != 16 ||
+		decoded.AudioFormat != wavFormatPCM || !bytes.Equal(decoded.Data, wav.Data) {
+		t.Fatalf("round trip mismatch: %+v", decoded)
+	}
+	if decoded.frames() != 8000 || decoded.duration() != time.Second {
+		t.Errorf("unexpected frames %d / duration %v", decoded.frames(), decoded.duration())
+	}
+
+	half := decoded.slice(4000, 9000)
+	if half.frames() != 4000 || !bytes.Equal(half.Data, wav.Data[4000*4:]) {
+		t.Errorf("unexpected slice with %d frames", half.frames())
+	}
+}
+
+func TestParseWAVErrors(t *testing.T) {
+	valid := wavAudio{Channels: 1, SampleRate: 8000, BitsPerSample: 16, Data: []byte{0, 0}}.encode()
+
+	testcases := map[string][]byte{
+		"not riff":     []byte("ID3 definitely not a wav"),
+		"missing data": valid[:36],
+		"zero channels": func() []byte {
+			b := append([]byte(nil), valid...)
+			b[22] = 0
+			return b
+		}(),
+	}
+	for name, data := range testcases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseWAV(data); !errors.Is(err, ErrInvalidWAV) {
+				t.Fatalf("expected ErrInvalidWAV, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWAVChannel(t *testing.T) {
+	wav := wavAudio{
+		Channels:      2,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+		Data:          []byte{1, 0, 2, 0, 3, 0, 4, 0},
+	}
+	left, right := wav.channel(0), wav.channel(1)
+	if left.Channels != 1 || !bytes.Equal(left.Data, []byte{1, 0, 3, 0}) {
+		t.Errorf("unexpected left channel: %+v", left)
+	}
+	if !bytes.Equal(right.Data, []byte{2, 0, 4, 0}) {
+		t.Errorf("unexpected right channel: %v", right.Data)
+	}
+}
+
+// extensibleWAV returns a WAVE_FORMAT_EXTENSIBLE file with a 24-bit PCM subformat.
+func extensibleWAV(fmtSize int) []byte {
+	var buf bytes.Buffer
+	data := make([]byte, 6*4) // 4 stereo frames of 24 bits
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4+8+fmtSize+8+len(data)))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(fmtSize))
+	fmtChunk := make([]byte, fmtSize)
+	binary.LittleEndian.PutUint16(fmtChunk[0:], 0xfffe)
+	binary.LittleEndian.PutUint16(fmtChunk[2:], 2)
+	binary.LittleEndian.PutUint32(fmtChunk[4:], 48000)
+	binary.LittleEndian.PutUint32(fmtChunk[8:], 48000*6)
+	binary.LittleEndian.PutUint16(fmtChunk[12:], 6)
+	binary.LittleEndian.PutUint16(fmtChunk[14:], 24)
+	if fmtSize >= 40 {
+		binary.LittleEndian.PutUint16(fmtChunk[16:], 22)
+		binary.LittleEndian.PutUint16(fmtChunk[24:], wavFormatPCM)
+	}
+	buf.Write(fmtChunk)
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func TestParseWAVExtensible(t *testing.T) {
+	wav, err := parseWAV(extensibleWAV(40))
+	if err != nil {
+		t.Fatalf("parseWAV error: %v", err)
+	}
+	if wav.AudioFormat != wavFormatPCM || wav.BitsPerSample != 24 || wav.frames() != 4 {
+		t.Fatalf("unexpected extensible WAV: %+v", wav)
+	}
+	reencoded, err := parseWAV(wav.encode())
+	if err != nil || reencoded.AudioFormat != wavFormatPCM {
+		t.Fatalf("re-encoded WAV is not plain PCM: %+v, %v", reencoded, err)
+	}
+
+	if _, err = parseWAV(extensibleWAV(18)); !errors.Is(err, ErrInvalidWAV) {
+		t.Fatalf("expected ErrInvalidWAV for a truncated extensible header, got %v", err)
+	}
+}